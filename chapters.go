@@ -0,0 +1,56 @@
+package types
+
+import "encoding/json"
+
+// MimetypeJSONChapters is the media type of a JSONChapters file, to be used in
+// PodcastChapters.
+const MimetypeJSONChapters = "application/json+chapters"
+
+// JSONChaptersVersion is the version of the JSON chapters format that is
+// written when JSONChapters.Version is empty.
+const JSONChaptersVersion = "1.2.0"
+
+// JSONChapters is the JSON chapters file that podcast:chapters links to. Read
+// more at
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/chapters/jsonChapters.md
+type JSONChapters struct {
+	Version     string        `json:"version"`
+	Author      *string       `json:"author,omitempty"`
+	Title       *string       `json:"title,omitempty"`
+	PodcastName *string       `json:"podcastName,omitempty"`
+	Description *string       `json:"description,omitempty"`
+	FileName    *string       `json:"fileName,omitempty"`
+	Waypoints   *bool         `json:"waypoints,omitempty"`
+	Chapters    []JSONChapter `json:"chapters"`
+}
+
+func (chapters JSONChapters) MarshalJSON() ([]byte, error) {
+	type plain JSONChapters
+	if chapters.Version == "" {
+		chapters.Version = JSONChaptersVersion
+	}
+	if chapters.Chapters == nil {
+		chapters.Chapters = []JSONChapter{}
+	}
+	return json.Marshal(plain(chapters))
+}
+
+// JSONChapter is a single chapter in JSONChapters. Chapters with TOC set to
+// false are not meant to be listed in a table of contents, e.g. when they
+// only change the artwork.
+type JSONChapter struct {
+	StartTime Duration             `json:"startTime"`
+	EndTime   *Duration            `json:"endTime,omitempty"`
+	Title     *string              `json:"title,omitempty"`
+	ImageURL  *string              `json:"img,omitempty"`
+	URL       *string              `json:"url,omitempty"`
+	TOC       *bool                `json:"toc,omitempty"`
+	Location  *JSONChapterLocation `json:"location,omitempty"`
+}
+
+// JSONChapterLocation is the location that a chapter is about.
+type JSONChapterLocation struct {
+	Name string      `json:"name"`
+	Geo  PodcastGeo  `json:"geo"`
+	OSM  *PodcastOSM `json:"osm,omitempty"`
+}
@@ -0,0 +1,318 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateStyle selects how an Encoder writes dates.
+type DateStyle int
+
+const (
+	// DateStyleGMT converts dates to UTC and writes them with the "GMT" zone,
+	// e.g. "Mon, 02 Jan 2006 20:04:05 GMT". This is the default.
+	DateStyleGMT DateStyle = iota
+	// DateStyleOffset keeps the zone offset of each date and writes it
+	// numerically, e.g. "Mon, 02 Jan 2006 15:04:05 -0500".
+	DateStyleOffset
+)
+
+func (pd Date) format(style DateStyle) string {
+	t := time.Time(pd)
+	if style == DateStyleOffset {
+		return t.Format(time.RFC1123Z)
+	}
+	return t.UTC().Format("Mon, 02 Jan 2006 15:04:05 GMT")
+}
+
+// DateIssue describes a way in which a parsed date deviates from the RFC 1123
+// form, e.g. "Mon, 02 Jan 2006 15:04:05 GMT", that RSS 2.0 requires.
+type DateIssue string
+
+const (
+	// DateIssueISO8601 means that the date is written in ISO 8601 instead.
+	DateIssueISO8601 DateIssue = "iso8601"
+	// DateIssueMissingWeekday means that the day of the week was left out.
+	DateIssueMissingWeekday DateIssue = "missing-weekday"
+	// DateIssueWrongWeekday means that the day of the week does not match the
+	// date. The date takes precedence.
+	DateIssueWrongWeekday DateIssue = "wrong-weekday"
+	// DateIssueLongName means that the day or month name is spelled out.
+	DateIssueLongName DateIssue = "long-name"
+	// DateIssueSingleDigitDay means that the day of the month is not padded
+	// to two digits.
+	DateIssueSingleDigitDay DateIssue = "single-digit-day"
+	// DateIssueTwoDigitYear means that the year has two digits. Years below
+	// 50 are taken to be in the 21st century, the rest in the 20th.
+	DateIssueTwoDigitYear DateIssue = "two-digit-year"
+	// DateIssueMissingSeconds means that the time has no seconds.
+	DateIssueMissingSeconds DateIssue = "missing-seconds"
+	// DateIssueMissingZone means that no time zone was given. UTC is assumed.
+	DateIssueMissingZone DateIssue = "missing-zone"
+	// DateIssueNonstandardZone means that the time zone is written in a form
+	// RFC 822 does not define, such as "CET" or "+05:30".
+	DateIssueNonstandardZone DateIssue = "nonstandard-zone"
+	// DateIssueUnknownZone means that the time zone was not recognised. UTC
+	// is assumed.
+	DateIssueUnknownZone DateIssue = "unknown-zone"
+)
+
+// rfc822Zones are the zone names defined by RFC 822, with their offsets in
+// hours.
+var rfc822Zones = map[string]int{
+	"UT": 0, "GMT": 0, "Z": 0,
+	"EST": -5, "EDT": -4,
+	"CST": -6, "CDT": -5,
+	"MST": -7, "MDT": -6,
+	"PST": -8, "PDT": -7,
+}
+
+// otherZones are zone names commonly found in feeds even though RFC 822 does
+// not define them, with their offsets in minutes.
+var otherZones = map[string]int{
+	"UTC": 0, "WET": 0, "WEST": 60, "BST": 60,
+	"CET": 60, "CEST": 120, "EET": 120, "EEST": 180, "MSK": 180,
+	"HKT": 480, "AWST": 480, "JST": 540, "KST": 540,
+	"ACST": 570, "ACDT": 630, "AEST": 600, "AEDT": 660,
+	"NZST": 720, "NZDT": 780,
+	"HST": -600, "AKST": -540, "AKDT": -480,
+	"AST": -240, "ADT": -180, "NST": -210, "NDT": -150,
+}
+
+// isoLayouts are the ISO 8601 variants accepted by ParseDate.
+var isoLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// ParseDate parses a date as found in the pubDate and lastBuildDate elements
+// of real-world feeds. Besides RFC 1123 dates it accepts missing or
+// mismatched weekdays, single-digit days, two-digit years, missing seconds,
+// named and numeric time zones as well as ISO 8601 dates. The returned issues
+// list the ways in which s deviates from RFC 1123; they are empty for a
+// conforming date.
+//
+// The time is returned in a fixed zone with the offset found in s, or in UTC
+// if s names UTC or has no zone.
+func ParseDate(s string) (time.Time, []DateIssue, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= 5 && isDigits(s[:4]) && s[4] == '-' {
+		return parseISODate(s)
+	}
+
+	// Drop a trailing comment such as "(UTC)".
+	if i := strings.IndexByte(s, '('); i > 0 && strings.HasSuffix(s, ")") {
+		s = s[:i]
+	}
+
+	var issues []DateIssue
+	fields := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	if len(fields) < 4 {
+		return time.Time{}, nil, fmt.Errorf("invalid date \"%s\"", s)
+	}
+
+	weekday, isLong, hasWeekday := parseName(fields[0], weekdayNames)
+	if hasWeekday {
+		fields = fields[1:]
+		if isLong {
+			issues = append(issues, DateIssueLongName)
+		}
+	} else {
+		issues = append(issues, DateIssueMissingWeekday)
+	}
+	if len(fields) < 4 || len(fields) > 5 {
+		return time.Time{}, nil, fmt.Errorf("invalid date \"%s\"", s)
+	}
+
+	day, err := strconv.Atoi(fields[0])
+	if err != nil || len(fields[0]) > 2 {
+		return time.Time{}, nil, fmt.Errorf("invalid day in date \"%s\"", s)
+	}
+	if len(fields[0]) == 1 {
+		issues = append(issues, DateIssueSingleDigitDay)
+	}
+
+	month, isLong, ok := parseName(fields[1], monthNames)
+	if !ok {
+		return time.Time{}, nil, fmt.Errorf("invalid month in date \"%s\"", s)
+	}
+	if isLong && !hasIssue(issues, DateIssueLongName) {
+		issues = append(issues, DateIssueLongName)
+	}
+
+	year, err := strconv.Atoi(fields[2])
+	switch {
+	case err != nil || (len(fields[2]) != 2 && len(fields[2]) != 4):
+		return time.Time{}, nil, fmt.Errorf("invalid year in date \"%s\"", s)
+	case len(fields[2]) == 2 && year < 50:
+		year += 2000
+		issues = append(issues, DateIssueTwoDigitYear)
+	case len(fields[2]) == 2:
+		year += 1900
+		issues = append(issues, DateIssueTwoDigitYear)
+	}
+
+	clock := strings.Split(fields[3], ":")
+	if len(clock) != 2 && len(clock) != 3 {
+		return time.Time{}, nil, fmt.Errorf("invalid time in date \"%s\"", s)
+	}
+	var hms [3]int
+	for i, c := range clock {
+		if hms[i], err = strconv.Atoi(c); err != nil || len(c) > 2 {
+			return time.Time{}, nil, fmt.Errorf("invalid time in date \"%s\"", s)
+		}
+	}
+	if len(clock) == 2 {
+		issues = append(issues, DateIssueMissingSeconds)
+	}
+	if hms[0] > 23 || hms[1] > 59 || hms[2] > 60 {
+		return time.Time{}, nil, fmt.Errorf("invalid time in date \"%s\"", s)
+	}
+
+	loc := time.UTC
+	if len(fields) == 5 {
+		var issue DateIssue
+		loc, issue, err = parseZone(fields[4])
+		if err != nil {
+			return time.Time{}, nil, fmt.Errorf("invalid time zone in date \"%s\"", s)
+		}
+		if issue != "" {
+			issues = append(issues, issue)
+		}
+	} else {
+		issues = append(issues, DateIssueMissingZone)
+	}
+
+	t := time.Date(year, time.Month(month), day, hms[0], hms[1], hms[2], 0, loc)
+	if t.Day() != day {
+		return time.Time{}, nil, fmt.Errorf("invalid day in date \"%s\"", s)
+	}
+	if hasWeekday && t.Weekday() != time.Weekday(weekday) {
+		issues = append(issues, DateIssueWrongWeekday)
+	}
+
+	return t, issues, nil
+}
+
+func parseISODate(s string) (time.Time, []DateIssue, error) {
+	for _, layout := range isoLayouts {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		issues := []DateIssue{DateIssueISO8601}
+		if !strings.Contains(layout, "Z07") {
+			issues = append(issues, DateIssueMissingZone)
+		}
+		if t.Location() != time.UTC {
+			_, offset := t.Zone()
+			t = t.In(time.FixedZone("", offset))
+		}
+		return t, issues, nil
+	}
+	return time.Time{}, nil, fmt.Errorf("invalid date \"%s\"", s)
+}
+
+// parseZone parses a named or numeric time zone.
+func parseZone(s string) (*time.Location, DateIssue, error) {
+	upper := strings.ToUpper(s)
+	if hours, ok := rfc822Zones[upper]; ok {
+		if hours == 0 {
+			return time.UTC, "", nil
+		}
+		return time.FixedZone(upper, hours*3600), "", nil
+	}
+	if minutes, ok := otherZones[upper]; ok {
+		if upper == "UTC" {
+			return time.UTC, DateIssueNonstandardZone, nil
+		}
+		return time.FixedZone(upper, minutes*60), DateIssueNonstandardZone, nil
+	}
+
+	var issue DateIssue
+	for _, prefix := range []string{"GMT", "UTC"} {
+		if strings.HasPrefix(upper, prefix) && len(upper) > len(prefix) {
+			s = s[len(prefix):]
+			issue = DateIssueNonstandardZone
+		}
+	}
+	if len(s) == 6 && s[3] == ':' {
+		s = s[:3] + s[4:]
+		issue = DateIssueNonstandardZone
+	}
+	if len(s) == 5 && (s[0] == '+' || s[0] == '-') && isDigits(s[1:]) {
+		hours, _ := strconv.Atoi(s[1:3])
+		minutes, _ := strconv.Atoi(s[3:])
+		offset := (hours*60 + minutes) * 60
+		if s[0] == '-' {
+			offset = -offset
+		}
+		if offset == 0 {
+			return time.UTC, issue, nil
+		}
+		return time.FixedZone("", offset), issue, nil
+	}
+
+	if issue == "" && isLetters(s) {
+		return time.UTC, DateIssueUnknownZone, nil
+	}
+	return nil, "", fmt.Errorf("invalid time zone \"%s\"", s)
+}
+
+var weekdayNames = []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"}
+
+var monthNames = []string{"", "january", "february", "march", "april", "may", "june", "july", "august", "september", "october", "november", "december"}
+
+// parseName looks s up in names, accepting both the full name and its
+// three-letter abbreviation regardless of case. It returns the index of the
+// name and whether s was the full name.
+func parseName(s string, names []string) (int, bool, bool) {
+	s = strings.ToLower(strings.TrimSuffix(s, "."))
+	for i, name := range names {
+		if name == "" {
+			continue
+		}
+		if s == name[:3] {
+			return i, false, true
+		}
+		if s == name {
+			return i, true, true
+		}
+	}
+	return 0, false, false
+}
+
+func hasIssue(issues []DateIssue, issue DateIssue) bool {
+	for _, i := range issues {
+		if i == issue {
+			return true
+		}
+	}
+	return false
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,252 @@
+package types
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// namespacePrefixes maps the namespaces known to this package onto the
+// prefixes used in the struct tags.
+var namespacePrefixes = map[string]string{
+	NamespaceAtom:       "atom",
+	NamespaceContent:    "content",
+	NamespaceGooglePlay: "googleplay",
+	NamespaceITunes:     "itunes",
+	NamespacePodcast:    "podcast",
+	NamespacePSC:        "psc",
+}
+
+// namespaceAliases lists namespace URIs that are found in real feeds in place
+// of the ones declared by this package. An empty value stands for RSS
+// elements, which have no namespace.
+var namespaceAliases = map[string]string{
+	"http://backend.userland.com/rss2":                                            "",
+	"https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md": NamespacePodcast,
+}
+
+// canonicalNamespaces maps normalised namespace URIs (see namespaceKey) onto
+// the namespaces they stand for.
+var canonicalNamespaces = func() map[string]string {
+	namespaces := make(map[string]string)
+	for namespace := range namespacePrefixes {
+		namespaces[namespaceKey(namespace)] = namespace
+	}
+	for alias, namespace := range namespaceAliases {
+		namespaces[namespaceKey(alias)] = namespace
+	}
+	return namespaces
+}()
+
+// namespaceKey normalises a namespace URI so that variations in case, scheme,
+// "www." and trailing slashes compare equal.
+func namespaceKey(uri string) string {
+	key := strings.ToLower(strings.TrimSpace(uri))
+	key = strings.TrimPrefix(key, "http://")
+	key = strings.TrimPrefix(key, "https://")
+	key = strings.TrimPrefix(key, "www.")
+	return strings.TrimRight(key, "/")
+}
+
+// canonicalNamespace returns the namespace constant that uri stands for, and
+// false if uri is not one of the namespaces known to this package.
+//
+// Besides variations of the URIs themselves, the canonical prefixes are
+// recognised too: xml.Decoder leaves the prefix in place of the namespace URI
+// when a feed uses a prefix without declaring it.
+func canonicalNamespace(uri string) (string, bool) {
+	if _, ok := namespacePrefixes[uri]; ok {
+		return uri, true
+	}
+	if namespace, ok := canonicalNamespaces[namespaceKey(uri)]; ok {
+		return namespace, true
+	}
+	for namespace, prefix := range namespacePrefixes {
+		if uri == prefix {
+			return namespace, true
+		}
+	}
+	return "", false
+}
+
+// prefixedName rewrites a name resolved by xml.Decoder into the form used by
+// the struct tags, e.g. {NamespaceITunes, "author"} becomes
+// {"", "itunes:author"}, whichever prefix the feed bound the namespace to.
+// Names from other namespaces are returned unchanged.
+func prefixedName(name xml.Name) xml.Name {
+	namespace, ok := canonicalNamespace(name.Space)
+	if !ok {
+		return name
+	}
+	if namespace == "" {
+		return xml.Name{Local: name.Local}
+	}
+	return xml.Name{Local: namespacePrefixes[namespace] + ":" + name.Local}
+}
+
+// decodeElement decodes start and everything up to its matching end element
+// into v, which must be a pointer to a struct.
+//
+// encoding/xml matches child elements by their namespace URI, whereas the
+// struct tags in this package contain the prefixes used for encoding. Types
+// whose children carry a prefix therefore implement UnmarshalXML by calling
+// decodeElement, which rewrites the children's names before looking up the
+// field they belong to.
+//
+// If v has an Extensions field, unknown attributes and children are kept in
+// ExtensionAttrs and Extensions rather than dropped.
+func decodeElement(d *xml.Decoder, start xml.StartElement, v interface{}) error {
+	defer pushScope(d, start.Attr)()
+	if err := decodeAttrs(d, start, v); err != nil {
+		return err
+	}
+	return decodeChildren(d, v)
+}
+
+// decodeAttrs decodes the attributes of start into v without consuming any
+// tokens from the underlying decoder. Names of attributes are rewritten as by
+// extensionAttr so that those not matching a field can be written back.
+func decodeAttrs(d *xml.Decoder, start xml.StartElement, v interface{}) error {
+	start.Name = prefixedName(start.Name)
+	var attrs []xml.Attr
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			continue
+		}
+		attrs = append(attrs, extensionAttr(d, attr, start.Attr))
+	}
+	start.Attr = attrs
+
+	tokens := tokenSlice{start, start.End()}
+	return xml.NewTokenDecoder(&tokens).Decode(v)
+}
+
+// decodeChildren decodes child elements into the matching fields of v until
+// the end of the current element is reached. Unknown children are appended to
+// the Extensions field of v if it has one and skipped otherwise.
+func decodeChildren(d *xml.Decoder, v interface{}) error {
+	fields := childFields(reflect.ValueOf(v).Elem())
+	extensions := reflect.ValueOf(v).Elem().FieldByName("Extensions")
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			name := prefixedName(t.Name)
+			field, ok := fields[name.Local]
+			if (!ok || name.Space != "") && extensions.IsValid() {
+				ext, err := decodeExtension(d, t)
+				if err != nil {
+					return err
+				}
+				extensions.Set(reflect.Append(extensions, reflect.ValueOf(ext)))
+				continue
+			}
+			if !ok || name.Space != "" {
+				if err := d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			t.Name = name
+			if err := decodeField(d, t, field); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// errAbsent is returned by UnmarshalXML methods whose element is to be read
+// as if it were missing, e.g. a blank pubDate.
+var errAbsent = errors.New("blank or unrecognised value")
+
+// decodeField decodes start into field, appending to it if it is a slice.
+// Elements whose UnmarshalXML returns errAbsent leave field as it was.
+func decodeField(d *xml.Decoder, start xml.StartElement, field reflect.Value) error {
+	if field.Kind() != reflect.Slice {
+		previous := reflect.New(field.Type()).Elem()
+		previous.Set(field)
+		err := d.DecodeElement(field.Addr().Interface(), &start)
+		if errors.Is(err, errAbsent) {
+			field.Set(previous)
+			return nil
+		}
+		return err
+	}
+
+	elem := reflect.New(field.Type().Elem())
+	if err := d.DecodeElement(elem.Interface(), &start); errors.Is(err, errAbsent) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	field.Set(reflect.Append(field, elem.Elem()))
+	return nil
+}
+
+// childFields indexes the fields of struct v that are encoded as child
+// elements by their element name.
+func childFields(v reflect.Value) map[string]reflect.Value {
+	fields := make(map[string]reflect.Value)
+	for i := 0; i < v.NumField(); i++ {
+		if name, ok := elementName(v.Type().Field(i)); ok {
+			fields[name] = v.Field(i)
+		}
+	}
+	return fields
+}
+
+// elementName returns the name of the element that encoding/xml produces for
+// field f, and false if f is not encoded as an element.
+func elementName(f reflect.StructField) (string, bool) {
+	if f.PkgPath != "" || f.Name == "XMLName" {
+		return "", false
+	}
+
+	tag := f.Tag.Get("xml")
+	if tag == "-" {
+		return "", false
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	for _, opt := range strings.Split(opts, ",") {
+		switch opt {
+		case "attr", "chardata", "cdata", "innerxml", "comment", "any":
+			return "", false
+		}
+	}
+	if name != "" {
+		return name, true
+	}
+
+	typ := f.Type
+	for typ.Kind() == reflect.Pointer || typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Struct {
+		if xmlName, ok := typ.FieldByName("XMLName"); ok {
+			if name, _, _ := strings.Cut(xmlName.Tag.Get("xml"), ","); name != "" {
+				return name, true
+			}
+		}
+	}
+	return f.Name, true
+}
+
+// tokenSlice is an xml.TokenReader over a fixed list of tokens.
+type tokenSlice []xml.Token
+
+func (tokens *tokenSlice) Token() (xml.Token, error) {
+	if len(*tokens) == 0 {
+		return nil, io.EOF
+	}
+	tok := (*tokens)[0]
+	*tokens = (*tokens)[1:]
+	return tok, nil
+}
@@ -0,0 +1,126 @@
+package types
+
+import (
+	"encoding/xml"
+	"io"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// Encoder writes feeds to an output stream. It works like xml.Encoder but
+// additionally lets the caller choose between alternative renderings of some
+// values.
+//
+// The options only apply to values written by Encode and EncodeElement of an
+// Encoder. MarshalXML methods receive nothing but the underlying xml.Encoder,
+// so while one of those calls is in progress, the options are registered
+// under that xml.Encoder for the methods to look up. Values encoded in any
+// other way, e.g. by xml.Marshal or by a MarshalXML method that creates its
+// own xml.Encoder, are written with the default options. The same goes for
+// attributes, as MarshalXMLAttr does not receive an encoder at all; types
+// with style-dependent attributes apply the options in their own MarshalXML.
+type Encoder struct {
+	e *xml.Encoder
+
+	// DateStyle selects how dates are written.
+	DateStyle DateStyle
+	// ExplicitStyle selects how itunes:explicit is written.
+	ExplicitStyle ExplicitStyle
+	// DurationStyle selects how itunes:duration is written.
+	DurationStyle DurationStyle
+}
+
+// NewEncoder returns a new encoder that writes to w using the default
+// options.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{e: xml.NewEncoder(w)}
+}
+
+// Encode writes the XML encoding of v to the stream, applying the options of
+// enc.
+func (enc *Encoder) Encode(v interface{}) error {
+	activeEncoders.Store(enc.e, enc)
+	defer activeEncoders.Delete(enc.e)
+	return enc.e.Encode(v)
+}
+
+// EncodeElement writes the XML encoding of v to the stream, using start as the
+// outermost tag and applying the options of enc.
+func (enc *Encoder) EncodeElement(v interface{}, start xml.StartElement) error {
+	activeEncoders.Store(enc.e, enc)
+	defer activeEncoders.Delete(enc.e)
+	return enc.e.EncodeElement(v, start)
+}
+
+// EncodeToken writes the given XML token to the stream, as
+// xml.Encoder.EncodeToken does.
+func (enc *Encoder) EncodeToken(t xml.Token) error {
+	return enc.e.EncodeToken(t)
+}
+
+// Indent sets the encoder to generate indented XML, as xml.Encoder.Indent
+// does.
+func (enc *Encoder) Indent(prefix, indent string) {
+	enc.e.Indent(prefix, indent)
+}
+
+// Flush flushes any buffered XML to the underlying writer.
+func (enc *Encoder) Flush() error {
+	return enc.e.Flush()
+}
+
+// activeEncoders maps each xml.Encoder that is in the middle of an Encode or
+// EncodeElement call of an Encoder to that Encoder. The underlying
+// xml.Encoder is not exported, so this is the only way for it to be used with
+// options.
+var activeEncoders sync.Map
+
+// encoderOptions returns the Encoder on whose behalf e is encoding, or an
+// Encoder with the default options if there is none.
+func encoderOptions(e *xml.Encoder) *Encoder {
+	if enc, ok := activeEncoders.Load(e); ok {
+		return enc.(*Encoder)
+	}
+	return &Encoder{}
+}
+
+// usedPrefixes adds to prefixes the namespace prefixes of all elements that
+// encoding/xml produces for v, including extensions.
+func usedPrefixes(v reflect.Value, prefixes map[string]bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			usedPrefixes(v.Elem(), prefixes)
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			usedPrefixes(v.Index(i), prefixes)
+		}
+	case reflect.Struct:
+		if ext, ok := v.Interface().(Extension); ok {
+			if prefix, _, ok := strings.Cut(ext.XMLName.Local, ":"); ok {
+				prefixes[prefix] = true
+			}
+			return
+		}
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			name, ok := elementName(f)
+			if !ok && f.Type != reflect.TypeOf([]Extension(nil)) {
+				continue
+			}
+			field := v.Field(i)
+			switch {
+			case field.Kind() == reflect.Pointer && field.IsNil(),
+				field.Kind() == reflect.Slice && field.Len() == 0,
+				strings.Contains(f.Tag.Get("xml"), ",omitempty") && field.IsZero():
+				continue
+			}
+			if prefix, _, ok := strings.Cut(name, ":"); ok {
+				prefixes[prefix] = true
+			}
+			usedPrefixes(field, prefixes)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package types
+
+import (
+	"encoding/xml"
+	"strings"
+	"sync"
+)
+
+// Extension is an element that this package does not model, such as a Media
+// RSS or Dublin Core element or a tag added to one of the supported namespaces
+// later on. Channel, Item and PodcastLiveItem keep such elements when decoding
+// and write them back unchanged when encoding.
+//
+// The name keeps the prefix used in the feed, e.g. "media:content". The
+// declarations of namespaces that this package does not know are kept in the
+// ExtensionAttrs of the element declaring them, usually RSS.
+//
+// InnerXML is only filled in when decoding from a byte stream, e.g. with
+// xml.Unmarshal or xml.NewDecoder, and is written out as is.
+type Extension struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	InnerXML string     `xml:",innerxml"`
+}
+
+// xmlNamespace is the namespace xml.Decoder resolves the reserved "xml"
+// prefix to, e.g. in "xml:lang".
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// decoderScopes maps each xml.Decoder that is in the middle of decoding an
+// element with namespace declarations to the prefixes in scope, keyed by
+// namespace. decodeExtension uses it to restore the prefixes of extension
+// names, which xml.Decoder replaces by namespaces. decodeElement pushes the
+// declarations of every element it decodes, so decoding an Item or Channel on
+// its own sees the declarations on that element and its descendants.
+var decoderScopes sync.Map
+
+// pushScope adds the namespace declarations among attrs to the scope of d and
+// returns a function that restores the previous scope.
+func pushScope(d *xml.Decoder, attrs []xml.Attr) func() {
+	var parent map[string]string
+	if scope, ok := decoderScopes.Load(d); ok {
+		parent = scope.(map[string]string)
+	}
+
+	var scope map[string]string
+	for _, attr := range attrs {
+		if attr.Name.Space != "xmlns" {
+			continue
+		}
+		if scope == nil {
+			scope = make(map[string]string)
+			for namespace, prefix := range parent {
+				scope[namespace] = prefix
+			}
+		}
+		scope[attr.Value] = attr.Name.Local
+	}
+	if scope == nil {
+		return func() {}
+	}
+
+	decoderScopes.Store(d, scope)
+	return func() {
+		if parent == nil {
+			decoderScopes.Delete(d)
+		} else {
+			decoderScopes.Store(d, parent)
+		}
+	}
+}
+
+// decodeExtension decodes start and everything up to its matching end element
+// into an Extension.
+func decodeExtension(d *xml.Decoder, start xml.StartElement) (Extension, error) {
+	ext := Extension{XMLName: extensionName(d, start.Name, start.Attr, true)}
+	for _, attr := range start.Attr {
+		ext.Attrs = append(ext.Attrs, extensionAttr(d, attr, start.Attr))
+	}
+
+	var inner struct {
+		XML string `xml:",innerxml"`
+	}
+	err := d.DecodeElement(&inner, &start)
+	ext.InnerXML = inner.XML
+	return ext, err
+}
+
+// extensionAttr rewrites attr, which was resolved by xml.Decoder, into the
+// form in which it appeared in the feed. attrs are the attributes of the
+// element that attr belongs to.
+func extensionAttr(d *xml.Decoder, attr xml.Attr, attrs []xml.Attr) xml.Attr {
+	if attr.Name.Space == "xmlns" {
+		// encoding/xml cannot write declarations in resolved form.
+		return xml.Attr{Name: xml.Name{Local: "xmlns:" + attr.Name.Local}, Value: attr.Value}
+	}
+	return xml.Attr{Name: extensionName(d, attr.Name, attrs, false), Value: attr.Value}
+}
+
+// extensionName rewrites a name resolved by xml.Decoder into the prefixed
+// form, e.g. {"http://search.yahoo.com/mrss/", "content"} becomes
+// {"", "media:content"}. The prefix is looked up among the declarations in
+// attrs, the attributes of the element the name belongs to, and then in the
+// scope of d. If no prefix is found, the namespace is kept and encoding/xml
+// declares it when encoding.
+func extensionName(d *xml.Decoder, name xml.Name, attrs []xml.Attr, isElement bool) xml.Name {
+	if name.Space == "" {
+		return name
+	}
+	if name.Space == xmlNamespace {
+		return xml.Name{Local: "xml:" + name.Local}
+	}
+	if _, ok := canonicalNamespace(name.Space); ok {
+		return prefixedName(name)
+	}
+
+	for _, attr := range attrs {
+		if attr.Name.Space == "xmlns" && attr.Value == name.Space {
+			return xml.Name{Local: attr.Name.Local + ":" + name.Local}
+		}
+	}
+	if isElement {
+		for _, attr := range attrs {
+			if attr.Name.Space == "" && attr.Name.Local == "xmlns" && attr.Value == name.Space {
+				return xml.Name{Local: name.Local}
+			}
+		}
+	}
+	if scope, ok := decoderScopes.Load(d); ok {
+		if prefix, ok := scope.(map[string]string)[name.Space]; ok {
+			return xml.Name{Local: prefix + ":" + name.Local}
+		}
+	}
+
+	// xml.Decoder keeps the prefix in place of the namespace if the feed did
+	// not declare it.
+	if !strings.Contains(name.Space, ":") {
+		return xml.Name{Local: name.Space + ":" + name.Local}
+	}
+	return name
+}
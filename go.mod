@@ -4,4 +4,4 @@ go 1.18
 
 require github.com/google/go-cmp v0.5.9
 
-require github.com/google/uuid v1.3.1 // indirect
+require github.com/google/uuid v1.3.1
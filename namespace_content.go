@@ -28,3 +28,14 @@ func (encoded ContentEncoded) MarshalXML(e *xml.Encoder, start xml.StartElement)
 		Encoded: encoded.Encoded,
 	}, start)
 }
+
+func (encoded *ContentEncoded) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	text, isCDATA, err := decodeText(d, start)
+	if err != nil {
+		return err
+	}
+	encoded.XMLName = prefixedName(start.Name)
+	encoded.Encoded = text
+	encoded.IsCDATA = isCDATA
+	return nil
+}
@@ -1,4 +1,129 @@
 package types
 
+import (
+	"encoding/xml"
+	"strings"
+)
+
 // NamespaceGooglePlay is the Google Play namespace.
 const NamespaceGooglePlay string = "http://www.google.com/schemas/play-podcasts/1.0"
+
+// GooglePlayImage is podcast's or episode's artwork for Google Podcasts.
+type GooglePlayImage struct {
+	XMLName xml.Name `xml:"googleplay:image"`
+	URL     string   `xml:"href,attr"`
+}
+
+// GooglePlayCategory is one of the categories defined by Google Podcasts.
+// Read more at
+// https://support.google.com/podcast-publishers/answer/9889544#category
+type GooglePlayCategory string
+
+const (
+	GooglePlayCategoryArts                       GooglePlayCategory = "Arts"
+	GooglePlayCategoryBusiness                   GooglePlayCategory = "Business"
+	GooglePlayCategoryComedy                     GooglePlayCategory = "Comedy"
+	GooglePlayCategoryEducation                  GooglePlayCategory = "Education"
+	GooglePlayCategoryGamesAndHobbies            GooglePlayCategory = "Games & Hobbies"
+	GooglePlayCategoryGovernmentAndOrganizations GooglePlayCategory = "Government & Organizations"
+	GooglePlayCategoryHealth                     GooglePlayCategory = "Health"
+	GooglePlayCategoryKidsAndFamily              GooglePlayCategory = "Kids & Family"
+	GooglePlayCategoryMusic                      GooglePlayCategory = "Music"
+	GooglePlayCategoryNewsAndPolitics            GooglePlayCategory = "News & Politics"
+	GooglePlayCategoryReligionAndSpirituality    GooglePlayCategory = "Religion & Spirituality"
+	GooglePlayCategoryScienceAndMedicine         GooglePlayCategory = "Science & Medicine"
+	GooglePlayCategorySocietyAndCulture          GooglePlayCategory = "Society & Culture"
+	GooglePlayCategorySportsAndRecreation        GooglePlayCategory = "Sports & Recreation"
+	GooglePlayCategoryTechnology                 GooglePlayCategory = "Technology"
+	GooglePlayCategoryTVAndFilm                  GooglePlayCategory = "TV & Film"
+)
+
+// GooglePlayCategories lists all categories defined by Google Podcasts.
+var GooglePlayCategories = []GooglePlayCategory{
+	GooglePlayCategoryArts,
+	GooglePlayCategoryBusiness,
+	GooglePlayCategoryComedy,
+	GooglePlayCategoryEducation,
+	GooglePlayCategoryGamesAndHobbies,
+	GooglePlayCategoryGovernmentAndOrganizations,
+	GooglePlayCategoryHealth,
+	GooglePlayCategoryKidsAndFamily,
+	GooglePlayCategoryMusic,
+	GooglePlayCategoryNewsAndPolitics,
+	GooglePlayCategoryReligionAndSpirituality,
+	GooglePlayCategoryScienceAndMedicine,
+	GooglePlayCategorySocietyAndCulture,
+	GooglePlayCategorySportsAndRecreation,
+	GooglePlayCategoryTechnology,
+	GooglePlayCategoryTVAndFilm,
+}
+
+func (c GooglePlayCategory) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(struct {
+		Category string `xml:"text,attr"`
+	}{
+		Category: string(c),
+	}, start)
+}
+
+func (c *GooglePlayCategory) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		Category string `xml:"text,attr"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	*c = GooglePlayCategory(v.Category)
+	return nil
+}
+
+// GooglePlayExplicit tells whether podcast or episode contains explicit
+// content. It is written as "yes" or "no"; "clean" is read as false.
+type GooglePlayExplicit bool
+
+func (explicit GooglePlayExplicit) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := "no"
+	if explicit {
+		v = "yes"
+	}
+	return e.EncodeElement(v, start)
+}
+
+// UnmarshalXML reads blank and unrecognised values as absent.
+func (explicit *GooglePlayExplicit) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "yes", "true":
+		*explicit = true
+	case "no", "clean", "false":
+		*explicit = false
+	default:
+		return errAbsent
+	}
+	return nil
+}
+
+// GooglePlayBlock tells Google Podcasts not to show the podcast or episode.
+// It is written as "yes" or "no"; any value other than "yes" is read as
+// false.
+type GooglePlayBlock bool
+
+func (block GooglePlayBlock) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := "no"
+	if block {
+		v = "yes"
+	}
+	return e.EncodeElement(v, start)
+}
+
+func (block *GooglePlayBlock) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	*block = GooglePlayBlock(strings.EqualFold(strings.TrimSpace(s), "yes"))
+	return nil
+}
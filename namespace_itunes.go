@@ -2,7 +2,10 @@ package types
 
 import (
 	"encoding/xml"
+	"fmt"
+	"math"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,13 +19,23 @@ type ITunesOwner struct {
 	Email   string   `xml:"itunes:email"`
 }
 
+func (owner *ITunesOwner) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain ITunesOwner
+	return decodeElement(d, start, (*plain)(owner))
+}
+
 // ITunesCategory denotes podcast's category information.
 type ITunesCategory struct {
 	XMLName     xml.Name           `xml:"itunes:category"`
-	Category    string             `xml:"text,attr"`
+	Category    ITunesCategoryName `xml:"text,attr"`
 	Subcategory *ITunesSubcategory `xml:"itunes:category"`
 }
 
+func (c *ITunesCategory) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain ITunesCategory
+	return decodeElement(d, start, (*plain)(c))
+}
+
 // ITunesSubcategory is more granural; it is a subset of Category.
 type ITunesSubcategory string
 
@@ -34,21 +47,263 @@ func (s ITunesSubcategory) MarshalXML(e *xml.Encoder, start xml.StartElement) er
 	}, start)
 }
 
+func (s *ITunesSubcategory) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		Category string `xml:"text,attr"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+	*s = ITunesSubcategory(v.Category)
+	return nil
+}
+
 // ITunesImage is podcast's or episode's artwork.
 type ITunesImage struct {
 	XMLName xml.Name `xml:"itunes:image"`
 	URL     string   `xml:"href,attr"`
 }
 
-// ITunesDuration denotesthe duration of an episode.
+// ITunesType tells whether episodes are meant to be listened to in any order
+// or from the oldest to the newest.
+type ITunesType string
+
+const (
+	ITunesTypeEpisodic ITunesType = "episodic"
+	ITunesTypeSerial   ITunesType = "serial"
+)
+
+func (t ITunesType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	switch t {
+	case ITunesTypeEpisodic, ITunesTypeSerial:
+		return e.EncodeElement(string(t), start)
+	default:
+		return fmt.Errorf("invalid itunes:type \"%s\"", t)
+	}
+}
+
+// UnmarshalXML ignores the case of known values. Unknown values are kept as
+// they are so that Validate can report them.
+func (t *ITunesType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s, err := decodeEnum(d, start, ITunesTypeEpisodic, ITunesTypeSerial)
+	*t = s
+	return err
+}
+
+// ITunesEpisodeType tells whether an episode is a regular one, a trailer or
+// bonus content.
+type ITunesEpisodeType string
+
+const (
+	ITunesEpisodeTypeFull    ITunesEpisodeType = "full"
+	ITunesEpisodeTypeTrailer ITunesEpisodeType = "trailer"
+	ITunesEpisodeTypeBonus   ITunesEpisodeType = "bonus"
+)
+
+func (t ITunesEpisodeType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	switch t {
+	case ITunesEpisodeTypeFull, ITunesEpisodeTypeTrailer, ITunesEpisodeTypeBonus:
+		return e.EncodeElement(string(t), start)
+	default:
+		return fmt.Errorf("invalid itunes:episodeType \"%s\"", t)
+	}
+}
+
+// UnmarshalXML ignores the case of known values. Unknown values are kept as
+// they are so that Validate can report them.
+func (t *ITunesEpisodeType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s, err := decodeEnum(d, start, ITunesEpisodeTypeFull, ITunesEpisodeTypeTrailer, ITunesEpisodeTypeBonus)
+	*t = s
+	return err
+}
+
+// decodeEnum decodes the text of start, replacing it by the value among
+// values that it matches regardless of case and surrounding space.
+func decodeEnum[T ~string](d *xml.Decoder, start xml.StartElement, values ...T) (T, error) {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return "", err
+	}
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(s), string(v)) {
+			return v, nil
+		}
+	}
+	return T(s), nil
+}
+
+// ITunesExplicit tells whether podcast or episode contains explicit content.
+// How it is written depends on the ExplicitStyle of the Encoder.
+type ITunesExplicit bool
+
+// ExplicitStyle selects how an Encoder writes itunes:explicit.
+type ExplicitStyle int
+
+const (
+	// ExplicitStyleTrueFalse writes "true" or "false", as Apple Podcasts
+	// currently documents. This is the default.
+	ExplicitStyleTrueFalse ExplicitStyle = iota
+	// ExplicitStyleYesClean writes "yes" or "clean", as older consumers
+	// expect.
+	ExplicitStyleYesClean
+)
+
+func (explicit ITunesExplicit) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	v := strconv.FormatBool(bool(explicit))
+	if encoderOptions(e).ExplicitStyle == ExplicitStyleYesClean {
+		v = "clean"
+		if explicit {
+			v = "yes"
+		}
+	}
+	return e.EncodeElement(v, start)
+}
+
+// UnmarshalXML accepts "true", "yes" and "explicit" as well as "false", "no"
+// and "clean", regardless of case. Blank and other values are read as absent,
+// so that Validate reports a missing itunes:explicit where it is required.
+func (explicit *ITunesExplicit) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "yes", "explicit":
+		*explicit = true
+	case "false", "no", "clean":
+		*explicit = false
+	default:
+		return errAbsent
+	}
+	return nil
+}
+
+// ITunesBlock tells Apple Podcasts not to show the podcast or episode. Only
+// "Yes" has an effect, so any other value is read as false, and false is
+// written as "No".
+type ITunesBlock bool
+
+func (block ITunesBlock) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(formatYes(bool(block)), start)
+}
+
+func (block *ITunesBlock) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	isYes, err := decodeYes(d, start)
+	*block = ITunesBlock(isYes)
+	return err
+}
+
+// ITunesComplete tells Apple Podcasts that no more episodes will be added to
+// the podcast. Only "Yes" has an effect, so any other value is read as false,
+// and false is written as "No".
+type ITunesComplete bool
+
+func (complete ITunesComplete) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(formatYes(bool(complete)), start)
+}
+
+func (complete *ITunesComplete) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	isYes, err := decodeYes(d, start)
+	*complete = ITunesComplete(isYes)
+	return err
+}
+
+func formatYes(isYes bool) string {
+	if isYes {
+		return "Yes"
+	}
+	return "No"
+}
+
+// decodeYes reports whether the text of start is "Yes", ignoring case and
+// surrounding space.
+func decodeYes(d *xml.Decoder, start xml.StartElement) (bool, error) {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return false, err
+	}
+	return strings.EqualFold(strings.TrimSpace(s), "yes"), nil
+}
+
+// ITunesDuration denotesthe duration of an episode. How it is written depends
+// on the DurationStyle of the Encoder. Negative durations are invalid.
 type ITunesDuration time.Duration
 
+// DurationStyle selects how an Encoder writes itunes:duration.
+type DurationStyle int
+
+const (
+	// DurationStyleSeconds writes the number of whole seconds, e.g. "3723".
+	// This is the default.
+	DurationStyleSeconds DurationStyle = iota
+	// DurationStyleHHMMSS writes hours, minutes and seconds, e.g.
+	// "01:02:03".
+	DurationStyleHHMMSS
+)
+
 func (d ITunesDuration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d < 0 {
+		return fmt.Errorf("invalid itunes:duration %s", time.Duration(d))
+	}
 	numSeconds := int(time.Duration(d).Seconds())
 
+	v := strconv.Itoa(numSeconds)
+	if encoderOptions(e).DurationStyle == DurationStyleHHMMSS {
+		v = fmt.Sprintf("%02d:%02d:%02d", numSeconds/3600, numSeconds/60%60, numSeconds%60)
+	}
+
 	return e.EncodeElement(struct {
 		Duration string `xml:",chardata"`
 	}{
-		Duration: strconv.Itoa(numSeconds),
+		Duration: v,
 	}, start)
 }
+
+// invalidITunesDuration is what UnmarshalXML decodes malformed durations
+// into, so that Validate can report them.
+const invalidITunesDuration = ITunesDuration(-1)
+
+// UnmarshalXML accepts "HH:MM:SS", "MM:SS" and plain seconds. Seconds may have
+// a fractional part. Blank durations are read as absent, and other values are
+// decoded as a negative duration rather than failing the whole feed.
+func (d *ITunesDuration) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := dec.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	if strings.TrimSpace(s) == "" {
+		return errAbsent
+	}
+	duration, err := parseITunesDuration(s)
+	if err != nil {
+		*d = invalidITunesDuration
+		return nil
+	}
+	*d = ITunesDuration(duration)
+	return nil
+}
+
+func parseITunesDuration(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid duration \"%s\"", s)
+	}
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds < 0 || math.IsNaN(seconds) || math.IsInf(seconds, 0) || (len(parts) > 1 && seconds >= 60) {
+		return 0, fmt.Errorf("invalid duration \"%s\"", s)
+	}
+	duration := time.Duration(math.Round(seconds * float64(time.Second)))
+
+	// Only the leading part may exceed its usual range, e.g. "90:00".
+	units := []time.Duration{time.Minute, time.Hour}
+	for i, part := range parts[:len(parts)-1] {
+		n, err := strconv.Atoi(part)
+		isLeading := i == 0
+		if err != nil || n < 0 || (!isLeading && n >= 60) {
+			return 0, fmt.Errorf("invalid duration \"%s\"", s)
+		}
+		duration += time.Duration(n) * units[len(parts)-2-i]
+	}
+	return duration, nil
+}
@@ -0,0 +1,242 @@
+package types
+
+import (
+	"bytes"
+	"encoding/xml"
+)
+
+// ITunesCategoryName is the name of one of the top-level categories of Apple
+// Podcasts. Read more at
+// https://podcasters.apple.com/support/1691-apple-podcasts-categories
+type ITunesCategoryName string
+
+const (
+	ITunesCategoryArts                    ITunesCategoryName = "Arts"
+	ITunesCategoryBusiness                ITunesCategoryName = "Business"
+	ITunesCategoryComedy                  ITunesCategoryName = "Comedy"
+	ITunesCategoryEducation               ITunesCategoryName = "Education"
+	ITunesCategoryFiction                 ITunesCategoryName = "Fiction"
+	ITunesCategoryGovernment              ITunesCategoryName = "Government"
+	ITunesCategoryHistory                 ITunesCategoryName = "History"
+	ITunesCategoryHealthAndFitness        ITunesCategoryName = "Health & Fitness"
+	ITunesCategoryKidsAndFamily           ITunesCategoryName = "Kids & Family"
+	ITunesCategoryLeisure                 ITunesCategoryName = "Leisure"
+	ITunesCategoryMusic                   ITunesCategoryName = "Music"
+	ITunesCategoryNews                    ITunesCategoryName = "News"
+	ITunesCategoryReligionAndSpirituality ITunesCategoryName = "Religion & Spirituality"
+	ITunesCategoryScience                 ITunesCategoryName = "Science"
+	ITunesCategorySocietyAndCulture       ITunesCategoryName = "Society & Culture"
+	ITunesCategorySports                  ITunesCategoryName = "Sports"
+	ITunesCategoryTechnology              ITunesCategoryName = "Technology"
+	ITunesCategoryTrueCrime               ITunesCategoryName = "True Crime"
+	ITunesCategoryTVAndFilm               ITunesCategoryName = "TV & Film"
+)
+
+const (
+	// Subcategories of ITunesCategoryArts.
+	ITunesSubcategoryBooks            ITunesSubcategory = "Books"
+	ITunesSubcategoryDesign           ITunesSubcategory = "Design"
+	ITunesSubcategoryFashionAndBeauty ITunesSubcategory = "Fashion & Beauty"
+	ITunesSubcategoryFood             ITunesSubcategory = "Food"
+	ITunesSubcategoryPerformingArts   ITunesSubcategory = "Performing Arts"
+	ITunesSubcategoryVisualArts       ITunesSubcategory = "Visual Arts"
+	// Subcategories of ITunesCategoryBusiness.
+	ITunesSubcategoryCareers          ITunesSubcategory = "Careers"
+	ITunesSubcategoryEntrepreneurship ITunesSubcategory = "Entrepreneurship"
+	ITunesSubcategoryInvesting        ITunesSubcategory = "Investing"
+	ITunesSubcategoryManagement       ITunesSubcategory = "Management"
+	ITunesSubcategoryMarketing        ITunesSubcategory = "Marketing"
+	ITunesSubcategoryNonProfit        ITunesSubcategory = "Non-Profit"
+	// Subcategories of ITunesCategoryComedy.
+	ITunesSubcategoryComedyInterviews ITunesSubcategory = "Comedy Interviews"
+	ITunesSubcategoryImprov           ITunesSubcategory = "Improv"
+	ITunesSubcategoryStandUp          ITunesSubcategory = "Stand-Up"
+	// Subcategories of ITunesCategoryEducation.
+	ITunesSubcategoryCourses          ITunesSubcategory = "Courses"
+	ITunesSubcategoryHowTo            ITunesSubcategory = "How To"
+	ITunesSubcategoryLanguageLearning ITunesSubcategory = "Language Learning"
+	ITunesSubcategorySelfImprovement  ITunesSubcategory = "Self-Improvement"
+	// Subcategories of ITunesCategoryFiction.
+	ITunesSubcategoryComedyFiction  ITunesSubcategory = "Comedy Fiction"
+	ITunesSubcategoryDrama          ITunesSubcategory = "Drama"
+	ITunesSubcategoryScienceFiction ITunesSubcategory = "Science Fiction"
+	// Subcategories of ITunesCategoryHealthAndFitness.
+	ITunesSubcategoryAlternativeHealth ITunesSubcategory = "Alternative Health"
+	ITunesSubcategoryFitness           ITunesSubcategory = "Fitness"
+	ITunesSubcategoryMedicine          ITunesSubcategory = "Medicine"
+	ITunesSubcategoryMentalHealth      ITunesSubcategory = "Mental Health"
+	ITunesSubcategoryNutrition         ITunesSubcategory = "Nutrition"
+	ITunesSubcategorySexuality         ITunesSubcategory = "Sexuality"
+	// Subcategories of ITunesCategoryKidsAndFamily.
+	ITunesSubcategoryEducationForKids ITunesSubcategory = "Education for Kids"
+	ITunesSubcategoryParenting        ITunesSubcategory = "Parenting"
+	ITunesSubcategoryPetsAndAnimals   ITunesSubcategory = "Pets & Animals"
+	ITunesSubcategoryStoriesForKids   ITunesSubcategory = "Stories for Kids"
+	// Subcategories of ITunesCategoryLeisure.
+	ITunesSubcategoryAnimationAndManga ITunesSubcategory = "Animation & Manga"
+	ITunesSubcategoryAutomotive        ITunesSubcategory = "Automotive"
+	ITunesSubcategoryAviation          ITunesSubcategory = "Aviation"
+	ITunesSubcategoryCrafts            ITunesSubcategory = "Crafts"
+	ITunesSubcategoryGames             ITunesSubcategory = "Games"
+	ITunesSubcategoryHobbies           ITunesSubcategory = "Hobbies"
+	ITunesSubcategoryHomeAndGarden     ITunesSubcategory = "Home & Garden"
+	ITunesSubcategoryVideoGames        ITunesSubcategory = "Video Games"
+	// Subcategories of ITunesCategoryMusic.
+	ITunesSubcategoryMusicCommentary ITunesSubcategory = "Music Commentary"
+	ITunesSubcategoryMusicHistory    ITunesSubcategory = "Music History"
+	ITunesSubcategoryMusicInterviews ITunesSubcategory = "Music Interviews"
+	// Subcategories of ITunesCategoryNews.
+	ITunesSubcategoryBusinessNews      ITunesSubcategory = "Business News"
+	ITunesSubcategoryDailyNews         ITunesSubcategory = "Daily News"
+	ITunesSubcategoryEntertainmentNews ITunesSubcategory = "Entertainment News"
+	ITunesSubcategoryNewsCommentary    ITunesSubcategory = "News Commentary"
+	ITunesSubcategoryPolitics          ITunesSubcategory = "Politics"
+	ITunesSubcategorySportsNews        ITunesSubcategory = "Sports News"
+	ITunesSubcategoryTechNews          ITunesSubcategory = "Tech News"
+	// Subcategories of ITunesCategoryReligionAndSpirituality.
+	ITunesSubcategoryBuddhism     ITunesSubcategory = "Buddhism"
+	ITunesSubcategoryChristianity ITunesSubcategory = "Christianity"
+	ITunesSubcategoryHinduism     ITunesSubcategory = "Hinduism"
+	ITunesSubcategoryIslam        ITunesSubcategory = "Islam"
+	ITunesSubcategoryJudaism      ITunesSubcategory = "Judaism"
+	ITunesSubcategoryReligion     ITunesSubcategory = "Religion"
+	ITunesSubcategorySpirituality ITunesSubcategory = "Spirituality"
+	// Subcategories of ITunesCategoryScience.
+	ITunesSubcategoryAstronomy       ITunesSubcategory = "Astronomy"
+	ITunesSubcategoryChemistry       ITunesSubcategory = "Chemistry"
+	ITunesSubcategoryEarthSciences   ITunesSubcategory = "Earth Sciences"
+	ITunesSubcategoryLifeSciences    ITunesSubcategory = "Life Sciences"
+	ITunesSubcategoryMathematics     ITunesSubcategory = "Mathematics"
+	ITunesSubcategoryNaturalSciences ITunesSubcategory = "Natural Sciences"
+	ITunesSubcategoryNature          ITunesSubcategory = "Nature"
+	ITunesSubcategoryPhysics         ITunesSubcategory = "Physics"
+	ITunesSubcategorySocialSciences  ITunesSubcategory = "Social Sciences"
+	// Subcategories of ITunesCategorySocietyAndCulture.
+	ITunesSubcategoryDocumentary      ITunesSubcategory = "Documentary"
+	ITunesSubcategoryPersonalJournals ITunesSubcategory = "Personal Journals"
+	ITunesSubcategoryPhilosophy       ITunesSubcategory = "Philosophy"
+	ITunesSubcategoryPlacesAndTravel  ITunesSubcategory = "Places & Travel"
+	ITunesSubcategoryRelationships    ITunesSubcategory = "Relationships"
+	// Subcategories of ITunesCategorySports.
+	ITunesSubcategoryBaseball      ITunesSubcategory = "Baseball"
+	ITunesSubcategoryBasketball    ITunesSubcategory = "Basketball"
+	ITunesSubcategoryCricket       ITunesSubcategory = "Cricket"
+	ITunesSubcategoryFantasySports ITunesSubcategory = "Fantasy Sports"
+	ITunesSubcategoryFootball      ITunesSubcategory = "Football"
+	ITunesSubcategoryGolf          ITunesSubcategory = "Golf"
+	ITunesSubcategoryHockey        ITunesSubcategory = "Hockey"
+	ITunesSubcategoryRugby         ITunesSubcategory = "Rugby"
+	ITunesSubcategoryRunning       ITunesSubcategory = "Running"
+	ITunesSubcategorySoccer        ITunesSubcategory = "Soccer"
+	ITunesSubcategorySwimming      ITunesSubcategory = "Swimming"
+	ITunesSubcategoryTennis        ITunesSubcategory = "Tennis"
+	ITunesSubcategoryVolleyball    ITunesSubcategory = "Volleyball"
+	ITunesSubcategoryWilderness    ITunesSubcategory = "Wilderness"
+	ITunesSubcategoryWrestling     ITunesSubcategory = "Wrestling"
+	// Subcategories of ITunesCategoryTVAndFilm.
+	ITunesSubcategoryAfterShows     ITunesSubcategory = "After Shows"
+	ITunesSubcategoryFilmHistory    ITunesSubcategory = "Film History"
+	ITunesSubcategoryFilmInterviews ITunesSubcategory = "Film Interviews"
+	ITunesSubcategoryFilmReviews    ITunesSubcategory = "Film Reviews"
+	ITunesSubcategoryTVReviews      ITunesSubcategory = "TV Reviews"
+)
+
+// itunesCategoryTree lists the categories of Apple Podcasts in the order in
+// which Apple lists them, together with their subcategories.
+var itunesCategoryTree = []struct {
+	category      ITunesCategoryName
+	subcategories []ITunesSubcategory
+}{
+	{ITunesCategoryArts, []ITunesSubcategory{ITunesSubcategoryBooks, ITunesSubcategoryDesign, ITunesSubcategoryFashionAndBeauty, ITunesSubcategoryFood, ITunesSubcategoryPerformingArts, ITunesSubcategoryVisualArts}},
+	{ITunesCategoryBusiness, []ITunesSubcategory{ITunesSubcategoryCareers, ITunesSubcategoryEntrepreneurship, ITunesSubcategoryInvesting, ITunesSubcategoryManagement, ITunesSubcategoryMarketing, ITunesSubcategoryNonProfit}},
+	{ITunesCategoryComedy, []ITunesSubcategory{ITunesSubcategoryComedyInterviews, ITunesSubcategoryImprov, ITunesSubcategoryStandUp}},
+	{ITunesCategoryEducation, []ITunesSubcategory{ITunesSubcategoryCourses, ITunesSubcategoryHowTo, ITunesSubcategoryLanguageLearning, ITunesSubcategorySelfImprovement}},
+	{ITunesCategoryFiction, []ITunesSubcategory{ITunesSubcategoryComedyFiction, ITunesSubcategoryDrama, ITunesSubcategoryScienceFiction}},
+	{ITunesCategoryGovernment, nil},
+	{ITunesCategoryHistory, nil},
+	{ITunesCategoryHealthAndFitness, []ITunesSubcategory{ITunesSubcategoryAlternativeHealth, ITunesSubcategoryFitness, ITunesSubcategoryMedicine, ITunesSubcategoryMentalHealth, ITunesSubcategoryNutrition, ITunesSubcategorySexuality}},
+	{ITunesCategoryKidsAndFamily, []ITunesSubcategory{ITunesSubcategoryEducationForKids, ITunesSubcategoryParenting, ITunesSubcategoryPetsAndAnimals, ITunesSubcategoryStoriesForKids}},
+	{ITunesCategoryLeisure, []ITunesSubcategory{ITunesSubcategoryAnimationAndManga, ITunesSubcategoryAutomotive, ITunesSubcategoryAviation, ITunesSubcategoryCrafts, ITunesSubcategoryGames, ITunesSubcategoryHobbies, ITunesSubcategoryHomeAndGarden, ITunesSubcategoryVideoGames}},
+	{ITunesCategoryMusic, []ITunesSubcategory{ITunesSubcategoryMusicCommentary, ITunesSubcategoryMusicHistory, ITunesSubcategoryMusicInterviews}},
+	{ITunesCategoryNews, []ITunesSubcategory{ITunesSubcategoryBusinessNews, ITunesSubcategoryDailyNews, ITunesSubcategoryEntertainmentNews, ITunesSubcategoryNewsCommentary, ITunesSubcategoryPolitics, ITunesSubcategorySportsNews, ITunesSubcategoryTechNews}},
+	{ITunesCategoryReligionAndSpirituality, []ITunesSubcategory{ITunesSubcategoryBuddhism, ITunesSubcategoryChristianity, ITunesSubcategoryHinduism, ITunesSubcategoryIslam, ITunesSubcategoryJudaism, ITunesSubcategoryReligion, ITunesSubcategorySpirituality}},
+	{ITunesCategoryScience, []ITunesSubcategory{ITunesSubcategoryAstronomy, ITunesSubcategoryChemistry, ITunesSubcategoryEarthSciences, ITunesSubcategoryLifeSciences, ITunesSubcategoryMathematics, ITunesSubcategoryNaturalSciences, ITunesSubcategoryNature, ITunesSubcategoryPhysics, ITunesSubcategorySocialSciences}},
+	{ITunesCategorySocietyAndCulture, []ITunesSubcategory{ITunesSubcategoryDocumentary, ITunesSubcategoryPersonalJournals, ITunesSubcategoryPhilosophy, ITunesSubcategoryPlacesAndTravel, ITunesSubcategoryRelationships}},
+	{ITunesCategorySports, []ITunesSubcategory{ITunesSubcategoryBaseball, ITunesSubcategoryBasketball, ITunesSubcategoryCricket, ITunesSubcategoryFantasySports, ITunesSubcategoryFootball, ITunesSubcategoryGolf, ITunesSubcategoryHockey, ITunesSubcategoryRugby, ITunesSubcategoryRunning, ITunesSubcategorySoccer, ITunesSubcategorySwimming, ITunesSubcategoryTennis, ITunesSubcategoryVolleyball, ITunesSubcategoryWilderness, ITunesSubcategoryWrestling}},
+	{ITunesCategoryTechnology, nil},
+	{ITunesCategoryTrueCrime, nil},
+	{ITunesCategoryTVAndFilm, []ITunesSubcategory{ITunesSubcategoryAfterShows, ITunesSubcategoryFilmHistory, ITunesSubcategoryFilmInterviews, ITunesSubcategoryFilmReviews, ITunesSubcategoryTVReviews}},
+}
+
+// ITunesCategoryNames returns the top-level categories of Apple Podcasts in
+// the order in which Apple lists them.
+func ITunesCategoryNames() []ITunesCategoryName {
+	names := make([]ITunesCategoryName, len(itunesCategoryTree))
+	for i, node := range itunesCategoryTree {
+		names[i] = node.category
+	}
+	return names
+}
+
+// IsValid reports whether c is a category of Apple Podcasts.
+func (c ITunesCategoryName) IsValid() bool {
+	_, ok := c.lookup()
+	return ok
+}
+
+// Subcategories returns the subcategories of c in the order in which Apple
+// lists them. It returns nil if c has none or is not a category of Apple Podcasts.
+func (c ITunesCategoryName) Subcategories() []ITunesSubcategory {
+	subcategories, _ := c.lookup()
+	return append([]ITunesSubcategory(nil), subcategories...)
+}
+
+func (c ITunesCategoryName) lookup() ([]ITunesSubcategory, bool) {
+	for _, node := range itunesCategoryTree {
+		if node.category == c {
+			return node.subcategories, true
+		}
+	}
+	return nil, false
+}
+
+// EscapedText returns c as it appears in the text attribute of a feed, e.g.
+// "Society &amp; Culture".
+func (c ITunesCategoryName) EscapedText() string {
+	return escapeText(string(c))
+}
+
+// Parent returns the category that s belongs to, and false if s is not a
+// subcategory of Apple Podcasts.
+func (s ITunesSubcategory) Parent() (ITunesCategoryName, bool) {
+	for _, node := range itunesCategoryTree {
+		for _, subcategory := range node.subcategories {
+			if subcategory == s {
+				return node.category, true
+			}
+		}
+	}
+	return "", false
+}
+
+// EscapedText returns s as it appears in the text attribute of a feed, e.g.
+// "Fashion &amp; Beauty".
+func (s ITunesSubcategory) EscapedText() string {
+	return escapeText(string(s))
+}
+
+// IsValid reports whether c names a category of Apple Podcasts and, if it has
+// a subcategory, whether that belongs to the category.
+func (c ITunesCategory) IsValid() bool {
+	if c.Subcategory == nil {
+		return c.Category.IsValid()
+	}
+	parent, ok := c.Subcategory.Parent()
+	return ok && parent == c.Category
+}
+
+func escapeText(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
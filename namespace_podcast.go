@@ -1,9 +1,11 @@
 package types
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,6 +20,33 @@ const NamespacePodcast string = "https://podcastindex.org/namespace/1.0"
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#guid
 type PodcastGUID string
 
+// podcastGUIDNamespace is the UUID namespace under which podcast:guid values
+// are generated.
+var podcastGUIDNamespace = uuid.MustParse("ead4c236-bf58-58c6-a2c6-a6b28d128cb6")
+
+// NewPodcastGUID generates the podcast:guid of the feed at feedURL as the
+// specification prescribes: the scheme and trailing slashes are removed from
+// the URL, which then serves as the name of a version 5 UUID.
+func NewPodcastGUID(feedURL string) PodcastGUID {
+	return PodcastGUID(podcastGUID(feedURL).String())
+}
+
+func podcastGUID(feedURL string) uuid.UUID {
+	feedURL = strings.TrimSpace(feedURL)
+	if _, rest, ok := strings.Cut(feedURL, "://"); ok {
+		feedURL = rest
+	}
+	feedURL = strings.TrimRight(feedURL, "/")
+	return uuid.NewSHA1(podcastGUIDNamespace, []byte(feedURL))
+}
+
+// IsValid reports whether guid is a version 5 UUID, as the specification
+// requires.
+func (guid PodcastGUID) IsValid() bool {
+	u, err := uuid.Parse(string(guid))
+	return err == nil && u.Version() == 5 && u.Variant() == uuid.RFC4122
+}
+
 // PodcastTranscript denotes episode's transcript. Read more at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#transcript
 type PodcastTranscript struct {
@@ -36,6 +65,215 @@ type PodcastChapters struct {
 	Mimetype string   `xml:"type,attr"`
 }
 
+// PodcastImages lists versions of podcast's or episode's artwork in different
+// sizes. They are written as a srcset, e.g.
+// "https://example.com/a-1500.jpg 1500w, https://example.com/a-600.jpg 600w".
+// Read more at
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#images
+type PodcastImages struct {
+	XMLName xml.Name `xml:"podcast:images"`
+	Images  []PodcastImage
+}
+
+// PodcastImage is a single image in PodcastImages. Width is given by its width
+// descriptor, e.g. 1500 for "1500w"; zero means that there is no descriptor.
+type PodcastImage struct {
+	URL   string
+	Width int
+}
+
+func (images PodcastImages) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "podcast:images"
+	start.Attr = append(start.Attr, xml.Attr{
+		Name:  xml.Name{Local: "srcset"},
+		Value: formatSrcset(images.Images),
+	})
+	return e.EncodeElement(struct{}{}, start)
+}
+
+func (images *PodcastImages) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		Srcset string `xml:"srcset,attr"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+
+	*images = PodcastImages{XMLName: start.Name, Images: parseSrcset(v.Srcset)}
+	return nil
+}
+
+// Best returns the smallest image that is at least width pixels wide, or the
+// widest image if there is none. Images without a width descriptor are only
+// returned if no image has one. It returns nil if there are no images.
+func (images PodcastImages) Best(width int) *PodcastImage {
+	var smallest, widest *PodcastImage
+	for i := range images.Images {
+		image := &images.Images[i]
+		if image.Width <= 0 {
+			continue
+		}
+		if image.Width >= width && (smallest == nil || image.Width < smallest.Width) {
+			smallest = image
+		}
+		if widest == nil || image.Width > widest.Width {
+			widest = image
+		}
+	}
+
+	switch {
+	case smallest != nil:
+		return smallest
+	case widest != nil:
+		return widest
+	case len(images.Images) > 0:
+		return &images.Images[0]
+	default:
+		return nil
+	}
+}
+
+func formatSrcset(images []PodcastImage) string {
+	var candidates []string
+	for _, image := range images {
+		candidate := image.URL
+		if image.Width != 0 {
+			candidate += fmt.Sprintf(" %dw", image.Width)
+		}
+		candidates = append(candidates, candidate)
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// parseSrcset parses image candidates separated by commas, each of which is a
+// URL optionally followed by a width descriptor. As in HTML, URLs may
+// themselves contain commas, except at their end. Candidates with any other
+// descriptor, such as "2x", are kept with a zero width for Validate to
+// report.
+func parseSrcset(srcset string) []PodcastImage {
+	var images []PodcastImage
+	s := srcset
+	for {
+		s = strings.TrimLeft(s, " \t\n\r\f,")
+		if s == "" {
+			return images
+		}
+
+		end := strings.IndexAny(s, " \t\n\r\f")
+		if end < 0 {
+			end = len(s)
+		}
+		image := PodcastImage{URL: strings.TrimRight(s[:end], ",")}
+		s = s[end:]
+
+		// A URL ending in a comma has no descriptor.
+		if len(image.URL) == end {
+			var descriptor string
+			descriptor, s, _ = strings.Cut(s, ",")
+			descriptor = strings.TrimSpace(descriptor)
+			if descriptor != "" {
+				digits := strings.TrimSuffix(descriptor, "w")
+				width, err := strconv.Atoi(digits)
+				if digits != descriptor && isDigits(digits) && err == nil && width > 0 {
+					image.Width = width
+				}
+			}
+		}
+		images = append(images, image)
+	}
+}
+
+// PodcastUpdateFrequency tells how often the podcast is expected to publish
+// episodes, both as a label such as "Weekly" and as a recurrence rule starting
+// at DTStart. Read more at
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#update-frequency
+type PodcastUpdateFrequency struct {
+	XMLName xml.Name `xml:"podcast:updateFrequency"`
+	Label   string
+	// Complete tells that no more episodes will be published.
+	Complete *bool
+	DTStart  *time.Time
+	RRule    *RRule
+}
+
+func (frequency PodcastUpdateFrequency) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "podcast:updateFrequency"
+	var dtstart *string
+	if frequency.DTStart != nil {
+		s := formatDTStart(*frequency.DTStart)
+		dtstart = &s
+	}
+	return e.EncodeElement(struct {
+		Complete *bool   `xml:"complete,attr"`
+		DTStart  *string `xml:"dtstart,attr"`
+		RRule    *RRule  `xml:"rrule,attr"`
+		Label    string  `xml:",chardata"`
+	}{
+		Complete: frequency.Complete,
+		DTStart:  dtstart,
+		RRule:    frequency.RRule,
+		Label:    frequency.Label,
+	}, start)
+}
+
+func (frequency *PodcastUpdateFrequency) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		Complete *bool   `xml:"complete,attr"`
+		DTStart  *string `xml:"dtstart,attr"`
+		RRule    *RRule  `xml:"rrule,attr"`
+		Label    string  `xml:",chardata"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+
+	*frequency = PodcastUpdateFrequency{
+		XMLName:  prefixedName(start.Name),
+		Label:    strings.TrimSpace(v.Label),
+		Complete: v.Complete,
+		RRule:    v.RRule,
+	}
+	if v.DTStart != nil {
+		dtstart, err := parseDTStart(*v.DTStart)
+		if err != nil {
+			return err
+		}
+		frequency.DTStart = &dtstart
+	}
+	return nil
+}
+
+// Next returns up to n times later than after at which episodes are expected
+// to be published, as computed by RRule.Next. It returns nil if the podcast
+// is complete or if DTStart or RRule is missing.
+func (frequency PodcastUpdateFrequency) Next(after time.Time, n int) ([]time.Time, error) {
+	if (frequency.Complete != nil && *frequency.Complete) || frequency.DTStart == nil || frequency.RRule == nil {
+		return nil, nil
+	}
+	return frequency.RRule.Next(*frequency.DTStart, after, n)
+}
+
+// formatDTStart formats t as an ISO 8601 date if it is midnight in UTC, and
+// as a date and time otherwise.
+func formatDTStart(t time.Time) string {
+	if t.Location() == time.UTC && t.Equal(t.Truncate(24*time.Hour)) {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339Nano)
+}
+
+// parseDTStart parses an ISO 8601 date or date and time. Times without a
+// zone are taken to be in UTC.
+func parseDTStart(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid dtstart \"%s\"", s)
+}
+
 // PodcastValue enables to describe Value 4 Value payments. Read more at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#value
 type PodcastValue struct {
@@ -47,6 +285,11 @@ type PodcastValue struct {
 	ValueTimeSplits []PodcastValueTimeSplit
 }
 
+func (value *PodcastValue) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain PodcastValue
+	return decodeElement(d, start, (*plain)(value))
+}
+
 // PodcastValueRecipient describes the recipient of Value 4 Value payments.
 // Read more at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#value
@@ -58,7 +301,7 @@ type PodcastValueRecipient struct {
 	Type        string   `xml:"type,attr"`
 	Address     string   `xml:"address,attr"`
 	Split       uint     `xml:"split,attr"`
-	Fee         *bool    `xml:"bool,attr"`
+	Fee         *bool    `xml:"fee,attr"`
 }
 
 // PodcastValueTimeSplit describes value splits that are valid for a certain period of time
@@ -74,6 +317,11 @@ type PodcastValueTimeSplit struct {
 	RemoteItem       PodcastRemoteItem
 }
 
+func (split *PodcastValueTimeSplit) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain PodcastValueTimeSplit
+	return decodeElement(d, start, (*plain)(split))
+}
+
 // PodcastRemoteItem provides a way to "point" to another feed or item in it.
 // Read more at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#remote-item
@@ -85,6 +333,16 @@ type PodcastRemoteItem struct {
 	Medium   *PodcastMedium `xml:"medium,attr"`
 }
 
+func (remoteItem PodcastRemoteItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// Derive a missing feed GUID from the feed URL.
+	if remoteItem.FeedGUID == uuid.Nil && remoteItem.FeedURL != nil {
+		remoteItem.FeedGUID = podcastGUID(*remoteItem.FeedURL)
+	}
+	start.Name.Local = "podcast:remoteItem"
+	type plain PodcastRemoteItem
+	return e.EncodeElement(plain(remoteItem), start)
+}
+
 // PodcastLocked tells podcast hosting platforms whether they are allowed to import
 // the feed. Read more at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#locked
@@ -108,13 +366,231 @@ func (l PodcastLocked) MarshalXML(e *xml.Encoder, start xml.StartElement) error
 	}, start)
 }
 
+func (l *PodcastLocked) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		Owner    *string `xml:"owner,attr"`
+		IsLocked string  `xml:",chardata"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+
+	l.XMLName = prefixedName(start.Name)
+	l.Owner = v.Owner
+	switch strings.ToLower(strings.TrimSpace(v.IsLocked)) {
+	case "yes":
+		l.IsLocked = true
+	case "no":
+		l.IsLocked = false
+	default:
+		return fmt.Errorf("invalid podcast:locked value \"%s\"", v.IsLocked)
+	}
+	return nil
+}
+
+// PodcastBlock tells a platform, or all platforms if Service is nil, whether it
+// may import and show the podcast. A channel may have one podcast:block for
+// all platforms and one for each platform, e.g. to block the podcast
+// everywhere except on one platform. Use Channel.IsBlocked to resolve them.
+// Read more at
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#block
+type PodcastBlock struct {
+	XMLName   xml.Name `xml:"podcast:block"`
+	Service   *PodcastService
+	IsBlocked bool
+}
+
+func (block PodcastBlock) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "podcast:block"
+	strBool := "no"
+	if block.IsBlocked {
+		strBool = "yes"
+	}
+	return e.EncodeElement(struct {
+		Service   *PodcastService `xml:"id,attr"`
+		IsBlocked string          `xml:",chardata"`
+	}{
+		Service:   block.Service,
+		IsBlocked: strBool,
+	}, start)
+}
+
+func (block *PodcastBlock) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		Service   *PodcastService `xml:"id,attr"`
+		IsBlocked string          `xml:",chardata"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+
+	block.XMLName = prefixedName(start.Name)
+	block.Service = v.Service
+	switch strings.ToLower(strings.TrimSpace(v.IsBlocked)) {
+	case "yes":
+		block.IsBlocked = true
+	case "no":
+		block.IsBlocked = false
+	default:
+		return fmt.Errorf("invalid podcast:block value \"%s\"", v.IsBlocked)
+	}
+	return nil
+}
+
+// IsBlocked reports whether the channel's podcast:block elements block the
+// podcast on the given platform. A podcast:block for the platform takes
+// precedence over one for all platforms, and of several for the same
+// platform, the first one counts.
+func (c Channel) IsBlocked(service PodcastService) bool {
+	var all *PodcastBlock
+	for i, block := range c.PodcastBlocks {
+		switch {
+		case block.Service == nil:
+			if all == nil {
+				all = &c.PodcastBlocks[i]
+			}
+		case *block.Service == service:
+			return block.IsBlocked
+		}
+	}
+	return all != nil && all.IsBlocked
+}
+
+// PodcastService is a slug that identifies a podcast platform. Read more at
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/serviceslugs.txt
+type PodcastService string
+
+var (
+	PodcastServiceAcast              PodcastService = "acast"
+	PodcastServiceAmazon             PodcastService = "amazon"
+	PodcastServiceAnchor             PodcastService = "anchor"
+	PodcastServiceApple              PodcastService = "apple"
+	PodcastServiceAudible            PodcastService = "audible"
+	PodcastServiceAudioboom          PodcastService = "audioboom"
+	PodcastServiceBacktracks         PodcastService = "backtracks"
+	PodcastServiceBitcoin            PodcastService = "bitcoin"
+	PodcastServiceBlubrry            PodcastService = "blubrry"
+	PodcastServiceBuzzsprout         PodcastService = "buzzsprout"
+	PodcastServiceCaptivate          PodcastService = "captivate"
+	PodcastServiceCastos             PodcastService = "castos"
+	PodcastServiceCastopod           PodcastService = "castopod"
+	PodcastServiceFacebook           PodcastService = "facebook"
+	PodcastServiceFireside           PodcastService = "fireside"
+	PodcastServiceFyyd               PodcastService = "fyyd"
+	PodcastServiceGoogle             PodcastService = "google"
+	PodcastServiceGPodder            PodcastService = "gpodder"
+	PodcastServiceHyperCatcher       PodcastService = "hypercatcher"
+	PodcastServiceKasts              PodcastService = "kasts"
+	PodcastServiceLibsyn             PodcastService = "libsyn"
+	PodcastServiceMastodon           PodcastService = "mastodon"
+	PodcastServiceMegafono           PodcastService = "megafono"
+	PodcastServiceMegaphone          PodcastService = "megaphone"
+	PodcastServiceOmnyStudio         PodcastService = "omnystudio"
+	PodcastServiceOvercast           PodcastService = "overcast"
+	PodcastServicePayPal             PodcastService = "paypal"
+	PodcastServicePinecast           PodcastService = "pinecast"
+	PodcastServicePodbean            PodcastService = "podbean"
+	PodcastServicePodcastAddict      PodcastService = "podcastaddict"
+	PodcastServicePodcastGuru        PodcastService = "podcastguru"
+	PodcastServicePodcastIndex       PodcastService = "podcastindex"
+	PodcastServicePodcasts           PodcastService = "podcasts"
+	PodcastServicePodchaser          PodcastService = "podchaser"
+	PodcastServicePodCloud           PodcastService = "podcloud"
+	PodcastServicePodfriend          PodcastService = "podfriend"
+	PodcastServicePodiant            PodcastService = "podiant"
+	PodcastServicePodigee            PodcastService = "podigee"
+	PodcastServicePodnews            PodcastService = "podnews"
+	PodcastServicePodomatic          PodcastService = "podomatic"
+	PodcastServicePodserve           PodcastService = "podserve"
+	PodcastServicePodverse           PodcastService = "podverse"
+	PodcastServiceRedCircle          PodcastService = "redcircle"
+	PodcastServiceRelay              PodcastService = "relay"
+	PodcastServiceResonateRecordings PodcastService = "resonaterecordings"
+	PodcastServiceRSS                PodcastService = "rss"
+	PodcastServiceShoutEngine        PodcastService = "shoutengine"
+	PodcastServiceSimplecast         PodcastService = "simplecast"
+	PodcastServiceSlack              PodcastService = "slack"
+	PodcastServiceSoundCloud         PodcastService = "soundcloud"
+	PodcastServiceSpotify            PodcastService = "spotify"
+	PodcastServiceSpreaker           PodcastService = "spreaker"
+	PodcastServiceTikTok             PodcastService = "tiktok"
+	PodcastServiceTransistor         PodcastService = "transistor"
+	PodcastServiceTwitter            PodcastService = "twitter"
+	PodcastServiceWhooshkaa          PodcastService = "whooshkaa"
+	PodcastServiceYouTube            PodcastService = "youtube"
+	PodcastServiceZencast            PodcastService = "zencast"
+)
+
+// PodcastServices lists all service slugs defined by the Podcasting 2.0
+// namespace.
+var PodcastServices = []PodcastService{
+	PodcastServiceAcast,
+	PodcastServiceAmazon,
+	PodcastServiceAnchor,
+	PodcastServiceApple,
+	PodcastServiceAudible,
+	PodcastServiceAudioboom,
+	PodcastServiceBacktracks,
+	PodcastServiceBitcoin,
+	PodcastServiceBlubrry,
+	PodcastServiceBuzzsprout,
+	PodcastServiceCaptivate,
+	PodcastServiceCastos,
+	PodcastServiceCastopod,
+	PodcastServiceFacebook,
+	PodcastServiceFireside,
+	PodcastServiceFyyd,
+	PodcastServiceGoogle,
+	PodcastServiceGPodder,
+	PodcastServiceHyperCatcher,
+	PodcastServiceKasts,
+	PodcastServiceLibsyn,
+	PodcastServiceMastodon,
+	PodcastServiceMegafono,
+	PodcastServiceMegaphone,
+	PodcastServiceOmnyStudio,
+	PodcastServiceOvercast,
+	PodcastServicePayPal,
+	PodcastServicePinecast,
+	PodcastServicePodbean,
+	PodcastServicePodcastAddict,
+	PodcastServicePodcastGuru,
+	PodcastServicePodcastIndex,
+	PodcastServicePodcasts,
+	PodcastServicePodchaser,
+	PodcastServicePodCloud,
+	PodcastServicePodfriend,
+	PodcastServicePodiant,
+	PodcastServicePodigee,
+	PodcastServicePodnews,
+	PodcastServicePodomatic,
+	PodcastServicePodserve,
+	PodcastServicePodverse,
+	PodcastServiceRedCircle,
+	PodcastServiceRelay,
+	PodcastServiceResonateRecordings,
+	PodcastServiceRSS,
+	PodcastServiceShoutEngine,
+	PodcastServiceSimplecast,
+	PodcastServiceSlack,
+	PodcastServiceSoundCloud,
+	PodcastServiceSpotify,
+	PodcastServiceSpreaker,
+	PodcastServiceTikTok,
+	PodcastServiceTransistor,
+	PodcastServiceTwitter,
+	PodcastServiceWhooshkaa,
+	PodcastServiceYouTube,
+	PodcastServiceZencast,
+}
+
 // PodcastLocation describes editorial focus of podcast's or episode's content.
 // Read more at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#location
 type PodcastLocation struct {
 	XMLName  xml.Name    `xml:"podcast:location"`
-	Geo      *PodcastGeo `xml:",attr,omitempty"`
-	OSM      *PodcastOSM `xml:",attr,omitempty"`
+	Geo      *PodcastGeo `xml:"geo,attr,omitempty"`
+	OSM      *PodcastOSM `xml:"osm,attr,omitempty"`
 	Location string      `xml:",chardata"`
 }
 
@@ -138,6 +614,43 @@ func (geo PodcastGeo) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return xml.Attr{Name: xml.Name{Local: "geo"}, Value: s}, nil
 }
 
+func (geo *PodcastGeo) UnmarshalXMLAttr(attr xml.Attr) error {
+	s := attr.Value
+	if len(s) < 4 || !strings.EqualFold(s[:4], "geo:") {
+		return fmt.Errorf("invalid geo URI \"%s\"", attr.Value)
+	}
+	coordinates, params, _ := strings.Cut(s[4:], ";")
+
+	var values []float64
+	for _, c := range strings.Split(coordinates, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(c), 64)
+		if err != nil {
+			return fmt.Errorf("invalid geo URI \"%s\"", attr.Value)
+		}
+		values = append(values, v)
+	}
+	if len(values) != 2 && len(values) != 3 {
+		return fmt.Errorf("invalid geo URI \"%s\"", attr.Value)
+	}
+
+	*geo = PodcastGeo{Latitude: values[0], Longitude: values[1]}
+	if len(values) == 3 {
+		geo.Altitude = &values[2]
+	}
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(param, "=")
+		if !strings.EqualFold(key, "u") {
+			continue
+		}
+		u, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid geo URI \"%s\"", attr.Value)
+		}
+		geo.Uncertainty = &u
+	}
+	return nil
+}
+
 // PodcastOSM encodes OpenStreetMap location information.
 type PodcastOSM struct {
 	Type      rune
@@ -154,6 +667,61 @@ func (osm PodcastOSM) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return xml.Attr{Name: xml.Name{Local: "osm"}, Value: s}, nil
 }
 
+func (osm *PodcastOSM) UnmarshalXMLAttr(attr xml.Attr) error {
+	s := attr.Value
+	if s == "" {
+		return fmt.Errorf("invalid OSM value \"%s\"", attr.Value)
+	}
+	id, revision, hasRevision := strings.Cut(s[1:], "#")
+
+	featureID, err := strconv.ParseUint(id, 10, 0)
+	if err != nil {
+		return fmt.Errorf("invalid OSM value \"%s\"", attr.Value)
+	}
+	*osm = PodcastOSM{Type: rune(s[0]), FeatureID: uint(featureID)}
+	if hasRevision {
+		r, err := strconv.ParseUint(revision, 10, 0)
+		if err != nil {
+			return fmt.Errorf("invalid OSM value \"%s\"", attr.Value)
+		}
+		rev := uint(r)
+		osm.Revision = &rev
+	}
+	return nil
+}
+
+func (geo PodcastGeo) MarshalJSON() ([]byte, error) {
+	attr, err := geo.MarshalXMLAttr(xml.Name{})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(attr.Value)
+}
+
+func (geo *PodcastGeo) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return geo.UnmarshalXMLAttr(xml.Attr{Value: s})
+}
+
+func (osm PodcastOSM) MarshalJSON() ([]byte, error) {
+	attr, err := osm.MarshalXMLAttr(xml.Name{})
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(attr.Value)
+}
+
+func (osm *PodcastOSM) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return osm.UnmarshalXMLAttr(xml.Attr{Value: s})
+}
+
 func removeTrailingZeros(f float64) string {
 	return strconv.FormatFloat(f, 'f', -1, 64)
 }
@@ -198,6 +766,29 @@ func (duration Duration) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 	return xml.Attr{Name: xml.Name{Local: name.Local}, Value: s}, nil
 }
 
+func (duration *Duration) UnmarshalXMLAttr(attr xml.Attr) error {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(attr.Value), 64)
+	if err != nil {
+		return fmt.Errorf("invalid duration \"%s\"", attr.Value)
+	}
+	*duration = Duration(math.Round(seconds * float64(time.Second)))
+	return nil
+}
+
+// MarshalJSON writes the duration as a number of seconds.
+func (duration Duration) MarshalJSON() ([]byte, error) {
+	return []byte(removeTrailingZeros(time.Duration(duration).Seconds())), nil
+}
+
+func (duration *Duration) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	*duration = Duration(math.Round(seconds * float64(time.Second)))
+	return nil
+}
+
 // DurationInteger denotes timestamps and durations during a podcast episode, but which are converted to integer seconds.
 type DurationInteger time.Duration
 
@@ -209,6 +800,15 @@ func (duration DurationInteger) MarshalXMLAttr(name xml.Name) (xml.Attr, error)
 	return xml.Attr{Name: xml.Name{Local: name.Local}, Value: s}, nil
 }
 
+func (duration *DurationInteger) UnmarshalXMLAttr(attr xml.Attr) error {
+	seconds, err := strconv.ParseFloat(strings.TrimSpace(attr.Value), 64)
+	if err != nil {
+		return fmt.Errorf("invalid duration \"%s\"", attr.Value)
+	}
+	*duration = DurationInteger(time.Duration(math.Round(seconds)) * time.Second)
+	return nil
+}
+
 // PodcastPerson specifies a person of interest to the podcast. Read more at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#person
 type PodcastPerson struct {
@@ -252,6 +852,26 @@ type PodcastTrailer struct {
 	Season   *int     `xml:"season,attr"`
 }
 
+func (trailer PodcastTrailer) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	// Do default except for the publish date, which depends on the Encoder.
+	start.Name.Local = "podcast:trailer"
+	return e.EncodeElement(struct {
+		Title    string  `xml:",chardata"`
+		PubDate  string  `xml:"pubdate,attr"`
+		URL      string  `xml:"url,attr"`
+		Length   *int64  `xml:"length,attr"`
+		Mimetype *string `xml:"type,attr"`
+		Season   *int    `xml:"season,attr"`
+	}{
+		Title:    trailer.Title,
+		PubDate:  trailer.PubDate.format(encoderOptions(e).DateStyle),
+		URL:      trailer.URL,
+		Length:   trailer.Length,
+		Mimetype: trailer.Mimetype,
+		Season:   trailer.Season,
+	}, start)
+}
+
 // PodcastMedium tells what the content contained within the feed is. Read more
 // at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#medium
@@ -311,6 +931,11 @@ type PodcastPublisher struct {
 	RemoteItems []PodcastRemoteItem
 }
 
+func (publisher *PodcastPublisher) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain PodcastPublisher
+	return decodeElement(d, start, (*plain)(publisher))
+}
+
 // PodcastAlternateEnclosure provides different versions of, or companion media to the main `<enclosure>` file.
 // Read more at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#alternate-enclosure
@@ -327,6 +952,11 @@ type PodcastAlternateEnclosure struct {
 	Sources      []PodcastSource
 }
 
+func (enclosure *PodcastAlternateEnclosure) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain PodcastAlternateEnclosure
+	return decodeElement(d, start, (*plain)(enclosure))
+}
+
 // PodcastSource defines a uri location for a `<podcast:alternateEnclosure>` media file.
 // Read more at
 // https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#source
@@ -342,6 +972,60 @@ type PodcastContentLink struct {
 	Text    string   `xml:",chardata"`
 }
 
+// PodcastSocialInteract links an episode to a thread where it can be discussed,
+// e.g. a post on Mastodon whose replies are shown as comments. A protocol of
+// "disabled" tells apps not to offer any. Read more at
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md#social-interact
+type PodcastSocialInteract struct {
+	XMLName    xml.Name              `xml:"podcast:socialInteract"`
+	URI        string                `xml:"uri,attr,omitempty"`
+	Protocol   PodcastSocialProtocol `xml:"protocol,attr"`
+	AccountID  *string               `xml:"accountId,attr"`
+	AccountURL *string               `xml:"accountUrl,attr"`
+	// Priority orders the threads when there is more than one; lower numbers
+	// come first.
+	Priority *int `xml:"priority,attr"`
+}
+
+// PodcastSocialProtocol is the protocol of a PodcastSocialInteract.
+type PodcastSocialProtocol string
+
+var (
+	PodcastSocialProtocolDisabled    PodcastSocialProtocol = "disabled"
+	PodcastSocialProtocolActivityPub PodcastSocialProtocol = "activitypub"
+	PodcastSocialProtocolATProto     PodcastSocialProtocol = "atproto"
+	PodcastSocialProtocolHive        PodcastSocialProtocol = "hive"
+	PodcastSocialProtocolLightning   PodcastSocialProtocol = "lightning"
+	PodcastSocialProtocolMatrix      PodcastSocialProtocol = "matrix"
+	PodcastSocialProtocolNostr       PodcastSocialProtocol = "nostr"
+	PodcastSocialProtocolTwitter     PodcastSocialProtocol = "twitter"
+)
+
+// IsDisabled reports whether the element disables social interaction for the
+// episode.
+func (interact PodcastSocialInteract) IsDisabled() bool {
+	return interact.Protocol == PodcastSocialProtocolDisabled
+}
+
+// PodcastSocialInteractsByPriority returns the threads that apps should offer
+// for an episode, in order of priority. Threads without a priority come last,
+// in the order they were given. It returns nil if any of interacts disables
+// social interaction.
+func PodcastSocialInteractsByPriority(interacts []PodcastSocialInteract) []PodcastSocialInteract {
+	for _, interact := range interacts {
+		if interact.IsDisabled() {
+			return nil
+		}
+	}
+
+	sorted := append([]PodcastSocialInteract(nil), interacts...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Priority, sorted[j].Priority
+		return a != nil && (b == nil || *a < *b)
+	})
+	return sorted
+}
+
 type PodcastLiveStatus string
 
 var (
@@ -357,15 +1041,18 @@ type PodcastLiveItem struct {
 	StartTime time.Time         `xml:"start,attr"`
 	EndTime   *time.Time        `xml:"end,attr,omitempty"`
 
+	Author                     *string `xml:"author"`
+	Categories                 []Category
+	Comments                   *string      `xml:"comments"`
 	Description                *Description `xml:"description"`
 	Enclosure                  *Enclosure
 	GUID                       *GUID
 	Link                       *string `xml:"link"`
 	Title                      *string `xml:"title"`
 	ContentEncoded             *ContentEncoded
-	ITunesEpisodeNumber        *int64  `xml:"itunes:episode"`
-	ITunesEpisodeType          *string `xml:"itunes:episodeType"`
-	ITunesExplicit             *bool   `xml:"itunes:explicit"`
+	ITunesEpisodeNumber        *int64             `xml:"itunes:episode"`
+	ITunesEpisodeType          *ITunesEpisodeType `xml:"itunes:episodeType"`
+	ITunesExplicit             *ITunesExplicit    `xml:"itunes:explicit"`
 	ITunesImage                *ITunesImage
 	ITunesSeasonNumber         *int64 `xml:"itunes:season"`
 	PodcastAlternateEnclosures []PodcastAlternateEnclosure
@@ -373,14 +1060,23 @@ type PodcastLiveItem struct {
 	PodcastContentLinks        []PodcastContentLink
 	PodcastEpisode             *PodcastEpisode
 	PodcastISRC                *PodcastISRC
+	PodcastImages              *PodcastImages
 	PodcastLiveValue           *PodcastLiveValue
 	PodcastLocation            *PodcastLocation
 	PodcastPersons             []PodcastPerson
 	PodcastSeason              *PodcastSeason
+	PodcastSocialInteracts     []PodcastSocialInteract
 	PodcastSoundbites          []PodcastSoundbite
 	PodcastTXTs                []PodcastTXT
 	PodcastTranscripts         []PodcastTranscript
 	PodcastValue               *PodcastValue
+	ExtensionAttrs             []xml.Attr  `xml:",any,attr"`
+	Extensions                 []Extension `xml:",any"`
+}
+
+func (item *PodcastLiveItem) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain PodcastLiveItem
+	return decodeElement(d, start, (*plain)(item))
 }
 
 // PodcastLiveValue is an experimental tag to transmit updates during a livestream.
@@ -3,7 +3,10 @@ package types
 import (
 	"encoding/xml"
 	"fmt"
+	"math"
 	"net/url"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -12,9 +15,14 @@ const NamespacePSC string = "http://podlove.org/simple-chapters"
 
 // PSCChapters is the root element for Podlove Simple Chapters.
 type PSCChapters struct {
-	XMLName  xml.Name `xml:"psc:chapters"`
-	Version  string   `xml:"version,attr"`
-	Chapters []PSCChapter
+	XMLName  xml.Name     `xml:"psc:chapters"`
+	Version  string       `xml:"version,attr"`
+	Chapters []PSCChapter `xml:"psc:chapter"`
+}
+
+func (chapters *PSCChapters) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain PSCChapters
+	return decodeElement(d, start, (*plain)(chapters))
 }
 
 // PSCChapter is a single chapter in Podlove Simple Chapters.
@@ -26,6 +34,10 @@ type PSCChapter struct {
 }
 
 func (encoded PSCChapter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if encoded.Start < 0 {
+		return fmt.Errorf("invalid chapter start %s", encoded.Start)
+	}
+
 	// Do default except for attributes, which we will marshal ourselves.
 	start.Name.Local = "psc:chapter"
 
@@ -52,11 +64,44 @@ func (encoded PSCChapter) MarshalXML(e *xml.Encoder, start xml.StartElement) err
 	return e.EncodeElement(struct{}{}, start)
 }
 
+func (encoded *PSCChapter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		Start string  `xml:"start,attr"`
+		Title string  `xml:"title,attr"`
+		Href  *string `xml:"href,attr"`
+		Image *string `xml:"image,attr"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+
+	chapterStart, err := parseTimestamp(v.Start)
+	if err != nil {
+		return err
+	}
+	*encoded = PSCChapter{Start: chapterStart, Title: v.Title}
+	if v.Href != nil {
+		if encoded.Href, err = url.Parse(*v.Href); err != nil {
+			return err
+		}
+	}
+	if v.Image != nil {
+		if encoded.Image, err = url.Parse(*v.Image); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatChapterStart formats start as a normal play time of the form
+// [HH:]MM:SS[.mmm], rounded to milliseconds. Hours are only written when
+// start is at least an hour, and milliseconds when there are any.
 func formatChapterStart(start time.Duration) string {
-	hours := int(start.Hours())
-	minutes := int(start.Minutes()) - hours*60
-	seconds := int(start.Seconds()) - minutes*60 - hours*3600
-	milliseconds := int(start.Milliseconds()) - seconds*1000 - minutes*60000 - hours*3600000
+	milliseconds := int64((start + time.Millisecond/2) / time.Millisecond)
+	hours := milliseconds / 3600000
+	minutes := milliseconds / 60000 % 60
+	seconds := milliseconds / 1000 % 60
+	milliseconds %= 1000
 
 	str := fmt.Sprintf("%02d:%02d", minutes, seconds)
 	if hours > 0 {
@@ -68,3 +113,42 @@ func formatChapterStart(start time.Duration) string {
 
 	return str
 }
+
+// parseTimestamp parses a normal play time of the form [[HH:]MM:]SS[.mmm], as
+// used by chapters and transcripts. The leading part may exceed its usual
+// range, e.g. "90:00" or "3723.5". Fractions of a second are rounded to
+// milliseconds.
+func parseTimestamp(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp \"%s\"", s)
+	}
+
+	last := parts[len(parts)-1]
+	whole, fraction, hasFraction := strings.Cut(last, ".")
+	if hasFraction && !isDigits(fraction) {
+		return 0, fmt.Errorf("invalid timestamp \"%s\"", s)
+	}
+	parts[len(parts)-1] = whole
+
+	var start time.Duration
+	for i, part := range parts {
+		isLeading := i == 0
+		if !isDigits(part) || (!isLeading && len(part) != 2) {
+			return 0, fmt.Errorf("invalid timestamp \"%s\"", s)
+		}
+		n, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || (!isLeading && n >= 60) || n > int64(math.MaxInt64/time.Hour) {
+			return 0, fmt.Errorf("invalid timestamp \"%s\"", s)
+		}
+		start = start*60 + time.Duration(n)*time.Second
+	}
+
+	if hasFraction {
+		// Round to milliseconds using the first four digits.
+		fraction = (fraction + "0000")[:4]
+		n, _ := strconv.Atoi(fraction)
+		start += time.Duration((n+5)/10) * time.Millisecond
+	}
+	return start, nil
+}
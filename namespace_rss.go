@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/xml"
+	"strings"
 )
 
 // RSSVersion denotes the RSS version.
@@ -36,6 +37,33 @@ func (d Description) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	}, start)
 }
 
+// UnmarshalXML decodes the description, recording whether it was wrapped in a
+// CDATA section. The latter is only detected when the decoder reads from a
+// byte stream rather than from an xml.TokenReader.
+func (d *Description) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	text, isCDATA, err := decodeText(dec, start)
+	if err != nil {
+		return err
+	}
+	d.XMLName = start.Name
+	d.Description = text
+	d.IsCDATA = isCDATA
+	return nil
+}
+
+// decodeText decodes the character data of start and reports whether it was
+// written as a CDATA section.
+func decodeText(d *xml.Decoder, start xml.StartElement) (string, bool, error) {
+	var v struct {
+		Text     string `xml:",chardata"`
+		InnerXML string `xml:",innerxml"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return "", false, err
+	}
+	return v.Text, strings.HasPrefix(strings.TrimSpace(v.InnerXML), "<![CDATA["), nil
+}
+
 // Enclosure is used to link to the episode's media file.
 type Enclosure struct {
 	XMLName  xml.Name `xml:"enclosure"`
@@ -50,3 +78,79 @@ type GUID struct {
 	GUID        string   `xml:",chardata"`
 	IsPermaLink *bool    `xml:"isPermaLink,attr"`
 }
+
+// Category places the channel or item in a category of a given taxonomy.
+type Category struct {
+	XMLName  xml.Name `xml:"category"`
+	Category string   `xml:",chardata"`
+	Domain   *string  `xml:"domain,attr"`
+}
+
+// Source names the channel that an item was republished from.
+type Source struct {
+	XMLName xml.Name `xml:"source"`
+	URL     string   `xml:"url,attr"`
+	Title   string   `xml:",chardata"`
+}
+
+// Image is a GIF, JPEG or PNG image that can be displayed with the channel.
+type Image struct {
+	XMLName     xml.Name `xml:"image"`
+	URL         string   `xml:"url"`
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Width       *int     `xml:"width"`
+	Height      *int     `xml:"height"`
+	Description *string  `xml:"description"`
+}
+
+const (
+	// MaxImageWidth and MaxImageHeight are the largest dimensions of an
+	// image allowed by RSS 2.0.
+	MaxImageWidth  = 144
+	MaxImageHeight = 400
+)
+
+// SkipHours lists the hours of the day, from 0 to 23 in GMT, during which
+// aggregators may skip reading the channel.
+type SkipHours struct {
+	XMLName xml.Name `xml:"skipHours"`
+	Hours   []int    `xml:"hour"`
+}
+
+// SkipDays lists the days of the week during which aggregators may skip
+// reading the channel. Days are named in English, e.g. "Saturday".
+type SkipDays struct {
+	XMLName xml.Name `xml:"skipDays"`
+	Days    []string `xml:"day"`
+}
+
+// Cloud tells aggregators how to register for notifications of updates to
+// the channel.
+type Cloud struct {
+	XMLName           xml.Name      `xml:"cloud"`
+	Domain            string        `xml:"domain,attr"`
+	Port              int           `xml:"port,attr"`
+	Path              string        `xml:"path,attr"`
+	RegisterProcedure string        `xml:"registerProcedure,attr"`
+	Protocol          CloudProtocol `xml:"protocol,attr"`
+}
+
+// CloudProtocol is the protocol used to register with a cloud.
+type CloudProtocol string
+
+const (
+	CloudProtocolXMLRPC   CloudProtocol = "xml-rpc"
+	CloudProtocolSOAP     CloudProtocol = "soap"
+	CloudProtocolHTTPPost CloudProtocol = "http-post"
+)
+
+// TextInput describes a text input box that can be displayed with the
+// channel.
+type TextInput struct {
+	XMLName     xml.Name `xml:"textInput"`
+	Title       string   `xml:"title"`
+	Description string   `xml:"description"`
+	Name        string   `xml:"name"`
+	Link        string   `xml:"link"`
+}
@@ -0,0 +1,413 @@
+package types
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RRule is a recurrence rule as defined by RFC 5545, limited to the FREQ,
+// INTERVAL, BYDAY, COUNT and UNTIL parts. Read more at
+// https://datatracker.ietf.org/doc/html/rfc5545#section-3.3.10
+type RRule struct {
+	Frequency RRuleFrequency
+	// Interval is the number of periods of Frequency between occurrences.
+	// Zero means 1.
+	Interval int
+	ByDay    []RRuleWeekday
+	// Count limits the number of occurrences, including the first one. Zero
+	// means no limit. It must not be combined with Until.
+	Count int
+	Until *time.Time
+}
+
+// RRuleFrequency is the type of period that a recurrence rule repeats in.
+type RRuleFrequency string
+
+const (
+	RRuleFrequencySecondly RRuleFrequency = "SECONDLY"
+	RRuleFrequencyMinutely RRuleFrequency = "MINUTELY"
+	RRuleFrequencyHourly   RRuleFrequency = "HOURLY"
+	RRuleFrequencyDaily    RRuleFrequency = "DAILY"
+	RRuleFrequencyWeekly   RRuleFrequency = "WEEKLY"
+	RRuleFrequencyMonthly  RRuleFrequency = "MONTHLY"
+	RRuleFrequencyYearly   RRuleFrequency = "YEARLY"
+)
+
+// RRuleWeekday is a day of the week in the BYDAY part of a recurrence rule.
+// For monthly and yearly rules, a non-zero Ordinal picks a single such day
+// in the month or year, e.g. 1 for the first Monday or -1 for the last one.
+type RRuleWeekday struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+func (day RRuleWeekday) String() string {
+	if day.Ordinal == 0 {
+		return rruleWeekdays[day.Weekday]
+	}
+	return fmt.Sprintf("%d%s", day.Ordinal, rruleWeekdays[day.Weekday])
+}
+
+// ParseRRule parses a recurrence rule such as "FREQ=WEEKLY;BYDAY=MO,TH".
+// Parts other than FREQ, INTERVAL, BYDAY, COUNT and UNTIL are not supported.
+func ParseRRule(s string) (*RRule, error) {
+	var rule RRule
+	seen := make(map[string]bool)
+	value := strings.TrimSpace(s)
+	if len(value) >= len("RRULE:") && strings.EqualFold(value[:len("RRULE:")], "RRULE:") {
+		value = value[len("RRULE:"):]
+	}
+	for _, part := range strings.Split(value, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(name)
+		if !ok || value == "" || seen[name] {
+			return nil, fmt.Errorf("invalid RRULE \"%s\"", s)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			rule.Frequency = RRuleFrequency(strings.ToUpper(value))
+		case "INTERVAL":
+			rule.Interval, err = parsePositiveInt(value)
+		case "COUNT":
+			rule.Count, err = parsePositiveInt(value)
+		case "UNTIL":
+			var until time.Time
+			until, err = parseRRuleTime(value)
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				var weekday RRuleWeekday
+				if weekday, err = parseRRuleWeekday(day); err != nil {
+					break
+				}
+				rule.ByDay = append(rule.ByDay, weekday)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported RRULE part \"%s\" in \"%s\"", name, s)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid RRULE \"%s\"", s)
+		}
+	}
+
+	if err := rule.check(); err != nil {
+		return nil, fmt.Errorf("invalid RRULE \"%s\": %w", s, err)
+	}
+	return &rule, nil
+}
+
+func parsePositiveInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 || !isDigits(s) {
+		return 0, fmt.Errorf("invalid positive integer \"%s\"", s)
+	}
+	return n, nil
+}
+
+// parseRRuleTime parses a date such as "20230101" or a date-time such as
+// "20230101T090000Z". Times without a zone are taken to be in UTC.
+func parseRRuleTime(s string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, strings.ToUpper(s)); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid RRULE time \"%s\"", s)
+}
+
+func parseRRuleWeekday(s string) (RRuleWeekday, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	if len(s) < 2 {
+		return RRuleWeekday{}, fmt.Errorf("invalid weekday \"%s\"", s)
+	}
+
+	var day RRuleWeekday
+	if ordinal := s[:len(s)-2]; ordinal != "" {
+		digits := strings.TrimLeft(ordinal, "+-")
+		n, err := strconv.Atoi(ordinal)
+		if err != nil || !isDigits(digits) || len(ordinal)-len(digits) > 1 || n == 0 {
+			return RRuleWeekday{}, fmt.Errorf("invalid weekday \"%s\"", s)
+		}
+		day.Ordinal = n
+	}
+	for i, name := range rruleWeekdays {
+		if s[len(s)-2:] == name {
+			day.Weekday = time.Weekday(i)
+			return day, nil
+		}
+	}
+	return RRuleWeekday{}, fmt.Errorf("invalid weekday \"%s\"", s)
+}
+
+// check returns an error if the rule cannot be written or evaluated.
+func (rule RRule) check() error {
+	switch rule.Frequency {
+	case RRuleFrequencySecondly, RRuleFrequencyMinutely, RRuleFrequencyHourly, RRuleFrequencyDaily,
+		RRuleFrequencyWeekly, RRuleFrequencyMonthly, RRuleFrequencyYearly:
+	default:
+		return fmt.Errorf("frequency \"%s\" is not one of SECONDLY, MINUTELY, HOURLY, DAILY, WEEKLY, MONTHLY or YEARLY", rule.Frequency)
+	}
+	if rule.Interval < 0 {
+		return fmt.Errorf("interval must not be negative")
+	}
+	if rule.Count < 0 {
+		return fmt.Errorf("count must not be negative")
+	}
+	if rule.Count > 0 && rule.Until != nil {
+		return fmt.Errorf("count and until must not both be set")
+	}
+	for _, day := range rule.ByDay {
+		if day.Weekday < time.Sunday || day.Weekday > time.Saturday {
+			return fmt.Errorf("weekday %d is out of range", day.Weekday)
+		}
+		if day.Ordinal == 0 {
+			continue
+		}
+		if rule.Frequency != RRuleFrequencyMonthly && rule.Frequency != RRuleFrequencyYearly {
+			return fmt.Errorf("weekday %s may only have an ordinal in monthly and yearly rules", day)
+		}
+		if day.Ordinal < -53 || day.Ordinal > 53 {
+			return fmt.Errorf("ordinal of weekday %s is out of range", day)
+		}
+	}
+	return nil
+}
+
+// String formats the rule, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH".
+func (rule RRule) String() string {
+	parts := []string{"FREQ=" + string(rule.Frequency)}
+	if rule.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", rule.Interval))
+	}
+	if len(rule.ByDay) > 0 {
+		var days []string
+		for _, day := range rule.ByDay {
+			days = append(days, day.String())
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if rule.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", rule.Count))
+	}
+	if rule.Until != nil {
+		parts = append(parts, "UNTIL="+rule.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (rule RRule) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if err := rule.check(); err != nil {
+		return xml.Attr{}, fmt.Errorf("invalid RRULE: %w", err)
+	}
+	return xml.Attr{Name: xml.Name{Local: name.Local}, Value: rule.String()}, nil
+}
+
+func (rule *RRule) UnmarshalXMLAttr(attr xml.Attr) error {
+	r, err := ParseRRule(attr.Value)
+	if err != nil {
+		return err
+	}
+	*rule = *r
+	return nil
+}
+
+// maxRRulePeriods bounds the number of periods that Next looks at, so that
+// rules whose BYDAY never matches do not loop forever.
+const maxRRulePeriods = 100000
+
+// ErrRRuleSearchLimit is returned by RRule.Next when it gives up looking for
+// further occurrences, which happens for rules whose BYDAY rarely or never
+// matches, such as "FREQ=MONTHLY;BYDAY=6MO".
+var ErrRRuleSearchLimit = errors.New("too many periods without an occurrence")
+
+// Next returns up to n occurrences of the rule that are later than after,
+// for a recurrence starting at dtstart. As in RFC 5545, dtstart is always the
+// first occurrence, and occurrences have its time of day and location. If
+// Next gives up after 100000 periods of the rule's frequency, it returns the
+// occurrences found so far along with ErrRRuleSearchLimit.
+func (rule RRule) Next(dtstart, after time.Time, n int) ([]time.Time, error) {
+	if err := rule.check(); err != nil {
+		return nil, fmt.Errorf("invalid RRULE: %w", err)
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	interval := rule.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	var occurrences []time.Time
+	count := 0
+	// add records an occurrence and reports whether more may follow.
+	add := func(t time.Time) bool {
+		if rule.Until != nil && t.After(*rule.Until) {
+			return false
+		}
+		count++
+		if t.After(after) {
+			occurrences = append(occurrences, t)
+		}
+		return len(occurrences) < n && (rule.Count == 0 || count < rule.Count)
+	}
+	if !add(dtstart) {
+		return occurrences, nil
+	}
+
+	// Without COUNT, periods well before after can be skipped.
+	first := 0
+	if rule.Count == 0 && after.After(dtstart) {
+		first = rule.periodsBetween(dtstart, after)/interval - 1
+		if first < 0 {
+			first = 0
+		}
+	}
+	for period := first; period < first+maxRRulePeriods; period++ {
+		for _, t := range rule.candidates(dtstart, period*interval) {
+			if !t.After(dtstart) {
+				continue
+			}
+			if !add(t) {
+				return occurrences, nil
+			}
+		}
+	}
+	return occurrences, ErrRRuleSearchLimit
+}
+
+// periodsBetween returns about how many periods of the rule's frequency lie
+// between from and to, erring on the low side.
+func (rule RRule) periodsBetween(from, to time.Time) int {
+	switch rule.Frequency {
+	case RRuleFrequencySecondly:
+		return int(to.Sub(from) / time.Second)
+	case RRuleFrequencyMinutely:
+		return int(to.Sub(from) / time.Minute)
+	case RRuleFrequencyHourly:
+		return int(to.Sub(from) / time.Hour)
+	case RRuleFrequencyDaily:
+		return int(to.Sub(from)/(24*time.Hour)) - 1
+	case RRuleFrequencyWeekly:
+		return int(to.Sub(from)/(7*24*time.Hour)) - 1
+	case RRuleFrequencyMonthly:
+		return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) - 1
+	default:
+		return to.Year() - from.Year() - 1
+	}
+}
+
+// candidates returns the times in order that the rule produces in the period
+// that is offset periods after the one containing dtstart.
+func (rule RRule) candidates(dtstart time.Time, offset int) []time.Time {
+	year, month, day := dtstart.Date()
+	hour, minute, sec := dtstart.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, sec, dtstart.Nanosecond(), dtstart.Location())
+	}
+
+	var times []time.Time
+	switch rule.Frequency {
+	case RRuleFrequencySecondly:
+		times = []time.Time{dtstart.Add(time.Duration(offset) * time.Second)}
+	case RRuleFrequencyMinutely:
+		times = []time.Time{dtstart.Add(time.Duration(offset) * time.Minute)}
+	case RRuleFrequencyHourly:
+		times = []time.Time{dtstart.Add(time.Duration(offset) * time.Hour)}
+	case RRuleFrequencyDaily:
+		times = []time.Time{at(year, month, day+offset)}
+	case RRuleFrequencyWeekly:
+		if len(rule.ByDay) == 0 {
+			return []time.Time{at(year, month, day+7*offset)}
+		}
+		// Weeks start on Monday.
+		monday := day + 7*offset - (int(dtstart.Weekday())+6)%7
+		for _, d := range rule.ByDay {
+			times = append(times, at(year, month, monday+(int(d.Weekday)+6)%7))
+		}
+		return sortTimes(times)
+	case RRuleFrequencyMonthly:
+		first := at(year, month+time.Month(offset), 1)
+		if len(rule.ByDay) == 0 {
+			return onDay(first, day)
+		}
+		return weekdaysIn(rule.ByDay, first, first.AddDate(0, 1, 0))
+	case RRuleFrequencyYearly:
+		first := at(year+offset, time.January, 1)
+		if len(rule.ByDay) == 0 {
+			return onDay(at(year+offset, month, 1), day)
+		}
+		return weekdaysIn(rule.ByDay, first, first.AddDate(1, 0, 0))
+	}
+
+	// Finer frequencies only use BYDAY to filter.
+	if len(rule.ByDay) > 0 {
+		for _, d := range rule.ByDay {
+			if d.Weekday == times[0].Weekday() {
+				return times
+			}
+		}
+		return nil
+	}
+	return times
+}
+
+// onDay returns the given day of the month that starts at first, or nothing
+// if the month is too short.
+func onDay(first time.Time, day int) []time.Time {
+	t := first.AddDate(0, 0, day-1)
+	if t.Month() != first.Month() {
+		return nil
+	}
+	return []time.Time{t}
+}
+
+// weekdaysIn returns the days from start up to end that match days.
+func weekdaysIn(days []RRuleWeekday, start, end time.Time) []time.Time {
+	var all []time.Time
+	for t := start; t.Before(end); t = t.AddDate(0, 0, 1) {
+		all = append(all, t)
+	}
+
+	var times []time.Time
+	for _, d := range days {
+		var matches []time.Time
+		for _, t := range all {
+			if t.Weekday() == d.Weekday {
+				matches = append(matches, t)
+			}
+		}
+		switch {
+		case d.Ordinal == 0:
+			times = append(times, matches...)
+		case d.Ordinal > 0 && d.Ordinal <= len(matches):
+			times = append(times, matches[d.Ordinal-1])
+		case d.Ordinal < 0 && -d.Ordinal <= len(matches):
+			times = append(times, matches[len(matches)+d.Ordinal])
+		}
+	}
+	return sortTimes(times)
+}
+
+// sortTimes sorts times and removes duplicates.
+func sortTimes(times []time.Time) []time.Time {
+	sort.Slice(times, func(i, j int) bool {
+		return times[i].Before(times[j])
+	})
+	var unique []time.Time
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,429 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"strings"
+	"time"
+)
+
+// Media types of the transcript formats that Transcript can be read from and
+// written in, to be used in PodcastTranscript.
+const (
+	MimetypeJSONTranscript = "application/json"
+	MimetypeSRT            = "application/x-subrip"
+	MimetypeWebVTT         = "text/vtt"
+	MimetypeHTML           = "text/html"
+)
+
+// TranscriptMimetypes lists the media types of all transcript formats that
+// Transcript supports.
+var TranscriptMimetypes = []string{
+	MimetypeJSONTranscript,
+	MimetypeSRT,
+	MimetypeWebVTT,
+	MimetypeHTML,
+}
+
+// TranscriptVersion is the version of the JSON transcript format that is
+// written when Transcript.Version is empty.
+const TranscriptVersion = "1.0.0"
+
+// Transcript is the content of a transcript file that podcast:transcript
+// links to. Its JSON encoding is the JSON transcript format; use Encode and
+// ParseTranscript for the other formats. Read more at
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/transcripts/transcripts.md
+type Transcript struct {
+	Version  string              `json:"version"`
+	Segments []TranscriptSegment `json:"segments"`
+}
+
+// TranscriptSegment is a part of a transcript spoken by a single speaker,
+// e.g. a cue of subtitles.
+type TranscriptSegment struct {
+	Speaker   string   `json:"speaker,omitempty"`
+	StartTime Duration `json:"startTime"`
+	EndTime   Duration `json:"endTime"`
+	Body      string   `json:"body"`
+}
+
+func (transcript Transcript) MarshalJSON() ([]byte, error) {
+	type plain Transcript
+	if transcript.Version == "" {
+		transcript.Version = TranscriptVersion
+	}
+	if transcript.Segments == nil {
+		transcript.Segments = []TranscriptSegment{}
+	}
+	return json.Marshal(plain(transcript))
+}
+
+// ParseTranscript reads a transcript in the format of the given media type,
+// which is one of TranscriptMimetypes. "application/srt" is accepted for SRT.
+func ParseTranscript(data []byte, mimetype string) (*Transcript, error) {
+	switch transcriptMediaType(mimetype) {
+	case MimetypeJSONTranscript:
+		var transcript Transcript
+		if err := json.Unmarshal(data, &transcript); err != nil {
+			return nil, err
+		}
+		return &transcript, nil
+	case MimetypeSRT:
+		return ParseSRT(data)
+	case MimetypeWebVTT:
+		return ParseWebVTT(data)
+	case MimetypeHTML:
+		return ParseTranscriptHTML(data)
+	default:
+		return nil, fmt.Errorf("unsupported transcript type \"%s\"", mimetype)
+	}
+}
+
+// Encode writes the transcript in the format of the given media type, which
+// is one of TranscriptMimetypes.
+func (transcript Transcript) Encode(mimetype string) ([]byte, error) {
+	switch transcriptMediaType(mimetype) {
+	case MimetypeJSONTranscript:
+		return json.Marshal(transcript)
+	case MimetypeSRT:
+		return transcript.SRT(), nil
+	case MimetypeWebVTT:
+		return transcript.WebVTT(), nil
+	case MimetypeHTML:
+		return transcript.HTML(), nil
+	default:
+		return nil, fmt.Errorf("unsupported transcript type \"%s\"", mimetype)
+	}
+}
+
+// transcriptMediaType drops the parameters of mimetype and replaces aliases
+// by the media types in TranscriptMimetypes.
+func transcriptMediaType(mimetype string) string {
+	mediaType, _, err := mime.ParseMediaType(mimetype)
+	if err != nil {
+		return mimetype
+	}
+	if mediaType == "application/srt" {
+		return MimetypeSRT
+	}
+	return mediaType
+}
+
+// SRT writes the transcript as SubRip subtitles. Speakers are written as
+// voice spans at the start of their cues, e.g. "<v Alice>Hello", as in
+// WebVTT; players that do not support them show the span as it is. Bodies
+// are written as they are, so one that starts with a voice span of its own is
+// read back as having a speaker. Blank lines in bodies are dropped, as they
+// would end the cue.
+func (transcript Transcript) SRT() []byte {
+	var b bytes.Buffer
+	for i, segment := range transcript.Segments {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "%d\n", i+1)
+		fmt.Fprintf(&b, "%s --> %s\n", formatTimestamp(time.Duration(segment.StartTime), ','), formatTimestamp(time.Duration(segment.EndTime), ','))
+		body := cueText(segment.Body)
+		if segment.Speaker != "" {
+			body = fmt.Sprintf("<v %s>%s", webVTTEscaper.Replace(segment.Speaker), body)
+		}
+		fmt.Fprintf(&b, "%s\n", body)
+	}
+	return b.Bytes()
+}
+
+// ParseSRT reads SubRip subtitles. A cue that starts with a voice span, e.g.
+// "<v Alice>Hello", is attributed to that speaker; other markup is kept in
+// bodies.
+func ParseSRT(data []byte) (*Transcript, error) {
+	transcript := Transcript{Version: TranscriptVersion}
+	for _, block := range cueBlocks(data) {
+		// The cue number is optional in practice.
+		if !strings.Contains(block[0], "-->") {
+			block = block[1:]
+		}
+		if len(block) == 0 {
+			return nil, errors.New("invalid SRT cue without timing")
+		}
+		startTime, endTime, err := parseCueTiming(strings.ReplaceAll(block[0], ",", "."))
+		if err != nil {
+			return nil, err
+		}
+
+		segment := TranscriptSegment{StartTime: startTime, EndTime: endTime}
+		segment.Body = strings.Join(block[1:], "\n")
+		if speaker, body, ok := cutVoiceSpan(segment.Body); ok {
+			segment.Speaker = html.UnescapeString(speaker)
+			segment.Body = strings.TrimSuffix(body, "</v>")
+		}
+		transcript.Segments = append(transcript.Segments, segment)
+	}
+	return &transcript, nil
+}
+
+// WebVTT writes the transcript as WebVTT subtitles. Speakers are written as
+// voice spans, e.g. "<v Alice>Hello". Blank lines in bodies are dropped, as
+// they would end the cue.
+func (transcript Transcript) WebVTT() []byte {
+	var b bytes.Buffer
+	b.WriteString("WEBVTT\n")
+	for _, segment := range transcript.Segments {
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "%s --> %s\n", formatTimestamp(time.Duration(segment.StartTime), '.'), formatTimestamp(time.Duration(segment.EndTime), '.'))
+		body := webVTTEscaper.Replace(cueText(segment.Body))
+		if segment.Speaker != "" {
+			body = fmt.Sprintf("<v %s>%s", webVTTEscaper.Replace(segment.Speaker), body)
+		}
+		fmt.Fprintf(&b, "%s\n", body)
+	}
+	return b.Bytes()
+}
+
+var webVTTEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// ParseWebVTT reads WebVTT subtitles. The speaker of a cue is taken from its
+// first voice span; other markup such as "<i>" is removed from bodies.
+// Comments, styles and regions are ignored.
+func ParseWebVTT(data []byte) (*Transcript, error) {
+	blocks := cueBlocks(data)
+	if len(blocks) == 0 || !isWebVTTHeader(blocks[0][0]) {
+		return nil, errors.New("invalid WebVTT file without a \"WEBVTT\" header")
+	}
+
+	transcript := Transcript{Version: TranscriptVersion}
+	for _, block := range blocks[1:] {
+		if !strings.Contains(block[0], "-->") {
+			switch strings.Fields(block[0])[0] {
+			case "NOTE", "STYLE", "REGION":
+				continue
+			}
+			// Skip the cue identifier.
+			block = block[1:]
+		}
+		if len(block) == 0 {
+			return nil, errors.New("invalid WebVTT cue without timing")
+		}
+		startTime, endTime, err := parseCueTiming(block[0])
+		if err != nil {
+			return nil, err
+		}
+
+		segment := TranscriptSegment{StartTime: startTime, EndTime: endTime}
+		text := strings.Join(block[1:], "\n")
+		if speaker, _, ok := cutVoiceSpan(text); ok {
+			segment.Speaker = html.UnescapeString(speaker)
+		}
+		segment.Body = html.UnescapeString(removeTags(text))
+		transcript.Segments = append(transcript.Segments, segment)
+	}
+	return &transcript, nil
+}
+
+func isWebVTTHeader(line string) bool {
+	line = strings.TrimPrefix(line, "\ufeff")
+	return line == "WEBVTT" || strings.HasPrefix(line, "WEBVTT ") || strings.HasPrefix(line, "WEBVTT\t")
+}
+
+// cutVoiceSpan slices a leading voice span such as "<v.loud Alice>" off text
+// and returns the speaker it names, which is empty if the span has no
+// annotation.
+func cutVoiceSpan(text string) (speaker, rest string, ok bool) {
+	if !strings.HasPrefix(text, "<v ") && !strings.HasPrefix(text, "<v.") {
+		return "", text, false
+	}
+	tag, rest, ok := strings.Cut(text, ">")
+	if !ok {
+		return "", text, false
+	}
+	_, speaker, _ = strings.Cut(tag, " ")
+	return strings.TrimSpace(speaker), rest, true
+}
+
+// removeTags removes everything between angle brackets.
+func removeTags(s string) string {
+	var b strings.Builder
+	isTag := false
+	for _, r := range s {
+		switch {
+		case r == '<':
+			isTag = true
+		case r == '>' && isTag:
+			isTag = false
+		case !isTag:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// HTML writes the transcript in the HTML transcript format, where speakers are
+// given in cite elements whenever they change and each body is preceded by
+// its start time. End times are not written.
+func (transcript Transcript) HTML() []byte {
+	var b bytes.Buffer
+	speaker := ""
+	for _, segment := range transcript.Segments {
+		if segment.Speaker != speaker {
+			if segment.Speaker == "" {
+				b.WriteString("<cite></cite>\n")
+			} else {
+				fmt.Fprintf(&b, "<cite>%s:</cite>\n", html.EscapeString(segment.Speaker))
+			}
+			speaker = segment.Speaker
+		}
+		fmt.Fprintf(&b, "<time>%s</time>\n", formatTimestamp(time.Duration(segment.StartTime), '.'))
+		fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(segment.Body))
+	}
+	return b.Bytes()
+}
+
+// ParseTranscriptHTML reads a transcript in the HTML transcript format. As the
+// format has no end times, each segment is taken to end when the next one
+// starts, and the last one to end when it starts.
+func ParseTranscriptHTML(data []byte) (*Transcript, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	transcript := Transcript{Version: TranscriptVersion}
+	var speaker string
+	var startTime time.Duration
+	for {
+		token, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(start.Name.Local) {
+		case "cite":
+			text, err := innerText(d)
+			if err != nil {
+				return nil, err
+			}
+			speaker = strings.TrimSuffix(strings.TrimSpace(text), ":")
+		case "time":
+			text, err := innerText(d)
+			if err != nil {
+				return nil, err
+			}
+			if startTime, err = parseTimestamp(text); err != nil {
+				return nil, err
+			}
+		case "p":
+			text, err := innerText(d)
+			if err != nil {
+				return nil, err
+			}
+			transcript.Segments = append(transcript.Segments, TranscriptSegment{
+				Speaker:   speaker,
+				StartTime: Duration(startTime),
+				EndTime:   Duration(startTime),
+				Body:      strings.TrimSpace(text),
+			})
+		}
+	}
+
+	for i := range transcript.Segments {
+		if i+1 < len(transcript.Segments) {
+			transcript.Segments[i].EndTime = transcript.Segments[i+1].StartTime
+		}
+	}
+	return &transcript, nil
+}
+
+// innerText reads the character data up to the end of the element whose
+// start was just read, including that of any nested elements.
+func innerText(d *xml.Decoder) (string, error) {
+	var b strings.Builder
+	for depth := 1; depth > 0; {
+		token, err := d.Token()
+		if err != nil {
+			return "", err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			b.Write(t)
+		}
+	}
+	return b.String(), nil
+}
+
+// cueBlocks splits subtitles into blocks of non-blank lines.
+func cueBlocks(data []byte) [][]string {
+	s := strings.TrimPrefix(string(data), "\ufeff")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+
+	var blocks [][]string
+	var block []string
+	for _, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if block != nil {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, strings.TrimRight(line, " \t\r"))
+	}
+	if block != nil {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+// parseCueTiming parses a line of the form "00:01.000 --> 00:02.000",
+// ignoring any cue settings that follow.
+func parseCueTiming(line string) (Duration, Duration, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 3 || fields[1] != "-->" {
+		return 0, 0, fmt.Errorf("invalid cue timing \"%s\"", line)
+	}
+	startTime, err := parseTimestamp(fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	endTime, err := parseTimestamp(fields[2])
+	if err != nil {
+		return 0, 0, err
+	}
+	return Duration(startTime), Duration(endTime), nil
+}
+
+// formatTimestamp formats d as HH:MM:SS followed by the separator and
+// milliseconds, e.g. "01:02:03,456" for SRT.
+func formatTimestamp(d time.Duration, separator byte) string {
+	if d < 0 {
+		d = 0
+	}
+	milliseconds := int64((d + time.Millisecond/2) / time.Millisecond)
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", milliseconds/3600000, milliseconds/60000%60, milliseconds/1000%60, separator, milliseconds%1000)
+}
+
+// cueText drops the blank lines of s, which would end a cue.
+func cueText(s string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
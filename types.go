@@ -3,6 +3,8 @@ package types
 import (
 	"encoding/xml"
 	"fmt"
+	"reflect"
+	"strings"
 	"time"
 )
 
@@ -17,9 +19,64 @@ type RSS struct {
 	NamespaceITunes     NSBool     `xml:",attr"`
 	NamespacePodcast    NSBool     `xml:",attr"`
 	NamespacePSC        NSBool     `xml:",attr"`
+	ExtensionAttrs      []xml.Attr `xml:",any,attr"`
 	Channel             Channel
 }
 
+func (rss *RSS) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	rss.XMLName = start.Name
+	for _, attr := range start.Attr {
+		switch {
+		case attr.Name.Space == "" && attr.Name.Local == "version":
+			rss.Version = RSSVersion(attr.Value)
+		case attr.Name.Space == "xmlns":
+			namespace, ok := canonicalNamespace(attr.Value)
+			if !ok || namespacePrefixes[namespace] != attr.Name.Local {
+				// Extensions may rely on the prefix being declared.
+				rss.ExtensionAttrs = append(rss.ExtensionAttrs, extensionAttr(d, attr, start.Attr))
+			}
+			switch namespace {
+			case NamespaceAtom:
+				rss.NamespaceAtom = true
+			case NamespaceContent:
+				rss.NamespaceContent = true
+			case NamespaceGooglePlay:
+				rss.NamespaceGooglePlay = true
+			case NamespaceITunes:
+				rss.NamespaceITunes = true
+			case NamespacePodcast:
+				rss.NamespacePodcast = true
+			case NamespacePSC:
+				rss.NamespacePSC = true
+			}
+		case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+			// Elements are matched by their namespace regardless of the
+			// default namespace.
+		default:
+			rss.ExtensionAttrs = append(rss.ExtensionAttrs, extensionAttr(d, attr, start.Attr))
+		}
+	}
+
+	defer pushScope(d, start.Attr)()
+	return decodeChildren(d, rss)
+}
+
+// DeclareNamespaces sets the namespace fields of rss so that exactly the
+// namespaces used by rss.Channel are declared, removing declarations that are
+// not needed. To declare a namespace regardless, set its field after calling
+// DeclareNamespaces.
+func (rss *RSS) DeclareNamespaces() {
+	prefixes := make(map[string]bool)
+	usedPrefixes(reflect.ValueOf(rss.Channel), prefixes)
+
+	rss.NamespaceAtom = NSBool(prefixes["atom"])
+	rss.NamespaceContent = NSBool(prefixes["content"])
+	rss.NamespaceGooglePlay = NSBool(prefixes["googleplay"])
+	rss.NamespaceITunes = NSBool(prefixes["itunes"])
+	rss.NamespacePodcast = NSBool(prefixes["podcast"])
+	rss.NamespacePSC = NSBool(prefixes["psc"])
+}
+
 type NSBool bool
 
 func (isPresent *NSBool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
@@ -51,83 +108,168 @@ func (isPresent *NSBool) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
 
 // Channel represents the podcast's feed.
 type Channel struct {
-	XMLName            xml.Name     `xml:"channel"`
-	Copyright          *string      `xml:"copyright"`
-	Description        *Description `xml:"description"`
-	Generator          *string      `xml:"generator"`
-	Language           *string      `xml:"language"`
-	LastBuildDate      *Date        `xml:"lastBuildDate"`
-	Link               *string      `xml:"link"`
-	Title              *string      `xml:"title"`
-	AtomLink           *AtomLink    `xml:"atom:link"`
-	ContentEncoded     *ContentEncoded
-	ITunesAuthor       *string `xml:"itunes:author"`
-	ITunesCategories   []ITunesCategory
-	ITunesExplicit     *bool `xml:"itunes:explicit"`
-	ITunesImage        *ITunesImage
-	ITunesNewFeedURL   *string `xml:"itunes:new-feed-url"`
-	ITunesOwner        *ITunesOwner
-	ITunesType         *string `xml:"itunes:type"`
-	PodcastFundings    []PodcastFunding
-	PodcastGUID        *PodcastGUID `xml:"podcast:guid"`
-	PodcastLicense     *PodcastLicense
-	PodcastLocation    *PodcastLocation
-	PodcastLocked      *PodcastLocked
-	PodcastMedium      *PodcastMedium `xml:"podcast:medium"`
-	PodcastPersons     []PodcastPerson
-	PodcastPodping     *PodcastPodping
-	PodcastPublisher   *PodcastPublisher
-	PodcastRemoteItems []PodcastRemoteItem
-	PodcastSingleItem  *PodcastSingleItem
-	PodcastTXTs        []PodcastTXT
-	PodcastTrailers    []PodcastTrailer
-	PodcastValue       *PodcastValue
-	PodcastLiveItems   []PodcastLiveItem
-	Items              []Item
+	XMLName                xml.Name `xml:"channel"`
+	Categories             []Category
+	Cloud                  *Cloud
+	Copyright              *string      `xml:"copyright"`
+	Description            *Description `xml:"description"`
+	Docs                   *string      `xml:"docs"`
+	Generator              *string      `xml:"generator"`
+	Image                  *Image
+	Language               *string `xml:"language"`
+	LastBuildDate          *Date   `xml:"lastBuildDate"`
+	Link                   *string `xml:"link"`
+	ManagingEditor         *string `xml:"managingEditor"`
+	PubDate                *Date   `xml:"pubDate"`
+	Rating                 *string `xml:"rating"`
+	SkipDays               *SkipDays
+	SkipHours              *SkipHours
+	TTL                    *int `xml:"ttl"`
+	TextInput              *TextInput
+	Title                  *string   `xml:"title"`
+	WebMaster              *string   `xml:"webMaster"`
+	AtomLink               *AtomLink `xml:"atom:link"`
+	ContentEncoded         *ContentEncoded
+	GooglePlayAuthor       *string              `xml:"googleplay:author"`
+	GooglePlayBlock        *GooglePlayBlock     `xml:"googleplay:block"`
+	GooglePlayCategories   []GooglePlayCategory `xml:"googleplay:category"`
+	GooglePlayDescription  *string              `xml:"googleplay:description"`
+	GooglePlayEmail        *string              `xml:"googleplay:email"`
+	GooglePlayExplicit     *GooglePlayExplicit  `xml:"googleplay:explicit"`
+	GooglePlayImage        *GooglePlayImage
+	GooglePlayNewFeedURL   *string      `xml:"googleplay:new-feed-url"`
+	GooglePlayOwner        *string      `xml:"googleplay:owner"`
+	ITunesAuthor           *string      `xml:"itunes:author"`
+	ITunesBlock            *ITunesBlock `xml:"itunes:block"`
+	ITunesCategories       []ITunesCategory
+	ITunesComplete         *ITunesComplete `xml:"itunes:complete"`
+	ITunesExplicit         *ITunesExplicit `xml:"itunes:explicit"`
+	ITunesImage            *ITunesImage
+	ITunesKeywords         *string `xml:"itunes:keywords"`
+	ITunesNewFeedURL       *string `xml:"itunes:new-feed-url"`
+	ITunesOwner            *ITunesOwner
+	ITunesSubtitle         *string     `xml:"itunes:subtitle"`
+	ITunesSummary          *string     `xml:"itunes:summary"`
+	ITunesTitle            *string     `xml:"itunes:title"`
+	ITunesType             *ITunesType `xml:"itunes:type"`
+	PodcastBlocks          []PodcastBlock
+	PodcastFundings        []PodcastFunding
+	PodcastGUID            *PodcastGUID `xml:"podcast:guid"`
+	PodcastImages          *PodcastImages
+	PodcastLicense         *PodcastLicense
+	PodcastLocation        *PodcastLocation
+	PodcastLocked          *PodcastLocked
+	PodcastMedium          *PodcastMedium `xml:"podcast:medium"`
+	PodcastPersons         []PodcastPerson
+	PodcastPodping         *PodcastPodping
+	PodcastPublisher       *PodcastPublisher
+	PodcastRemoteItems     []PodcastRemoteItem
+	PodcastSingleItem      *PodcastSingleItem
+	PodcastTXTs            []PodcastTXT
+	PodcastTrailers        []PodcastTrailer
+	PodcastUpdateFrequency *PodcastUpdateFrequency
+	PodcastValue           *PodcastValue
+	ExtensionAttrs         []xml.Attr  `xml:",any,attr"`
+	Extensions             []Extension `xml:",any"`
+	PodcastLiveItems       []PodcastLiveItem
+	Items                  []Item
+}
+
+func (c *Channel) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain Channel
+	return decodeElement(d, start, (*plain)(c))
 }
 
 // Item represents episode of a podcast.
 type Item struct {
-	XMLName                    xml.Name     `xml:"item"`
+	XMLName                    xml.Name `xml:"item"`
+	Author                     *string  `xml:"author"`
+	Categories                 []Category
+	Comments                   *string      `xml:"comments"`
 	Description                *Description `xml:"description"`
 	Enclosure                  *Enclosure
 	GUID                       *GUID
 	Link                       *string `xml:"link"`
 	PubDate                    *Date   `xml:"pubDate"`
+	Source                     *Source
 	Title                      *string `xml:"title"`
 	ContentEncoded             *ContentEncoded
-	ITunesDuration             *ITunesDuration `xml:"itunes:duration"`
-	ITunesEpisodeNumber        *int64          `xml:"itunes:episode"`
-	ITunesEpisodeType          *string         `xml:"itunes:episodeType"`
-	ITunesExplicit             *bool           `xml:"itunes:explicit"`
+	GooglePlayAuthor           *string             `xml:"googleplay:author"`
+	GooglePlayBlock            *GooglePlayBlock    `xml:"googleplay:block"`
+	GooglePlayDescription      *string             `xml:"googleplay:description"`
+	GooglePlayExplicit         *GooglePlayExplicit `xml:"googleplay:explicit"`
+	GooglePlayImage            *GooglePlayImage
+	ITunesAuthor               *string            `xml:"itunes:author"`
+	ITunesBlock                *ITunesBlock       `xml:"itunes:block"`
+	ITunesDuration             *ITunesDuration    `xml:"itunes:duration"`
+	ITunesEpisodeNumber        *int64             `xml:"itunes:episode"`
+	ITunesEpisodeType          *ITunesEpisodeType `xml:"itunes:episodeType"`
+	ITunesExplicit             *ITunesExplicit    `xml:"itunes:explicit"`
 	ITunesImage                *ITunesImage
-	ITunesSeasonNumber         *int64 `xml:"itunes:season"`
+	ITunesSeasonNumber         *int64  `xml:"itunes:season"`
+	ITunesSubtitle             *string `xml:"itunes:subtitle"`
+	ITunesSummary              *string `xml:"itunes:summary"`
+	ITunesTitle                *string `xml:"itunes:title"`
 	PodcastAlternateEnclosures []PodcastAlternateEnclosure
 	PodcastChapters            *PodcastChapters
 	PodcastEpisode             *PodcastEpisode
 	PodcastISRC                *PodcastISRC
+	PodcastImages              *PodcastImages
 	PodcastLicense             *PodcastLicense
 	PodcastLocation            *PodcastLocation
 	PodcastPersons             []PodcastPerson
 	PodcastSeason              *PodcastSeason
+	PodcastSocialInteracts     []PodcastSocialInteract
 	PodcastSoundbites          []PodcastSoundbite
 	PodcastTXTs                []PodcastTXT
 	PodcastTranscripts         []PodcastTranscript
 	PodcastValue               *PodcastValue
 	PSCChapters                *PSCChapters
+	ExtensionAttrs             []xml.Attr  `xml:",any,attr"`
+	Extensions                 []Extension `xml:",any"`
 }
 
-// Date is used to format the publish date of an episode.
+func (item *Item) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	type plain Item
+	return decodeElement(d, start, (*plain)(item))
+}
+
+// Date is used to format the publish date of an episode. Dates are written in
+// UTC unless an Encoder with a different DateStyle is used.
 type Date time.Time
 
 func (pd Date) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	t := time.Time(pd)
-	v := t.Format("Mon, 02 Jan 2006 15:04:05 GMT")
+	v := pd.format(encoderOptions(e).DateStyle)
 	return e.EncodeElement(v, start)
 }
 
+// MarshalXMLAttr always uses DateStyleGMT because attributes are encoded
+// without access to the Encoder. Types with date attributes implement
+// MarshalXML to apply the DateStyle themselves.
 func (pd Date) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
-	t := time.Time(pd)
-	v := t.Format("Mon, 02 Jan 2006 15:04:05 GMT")
+	v := pd.format(DateStyleGMT)
 	return xml.Attr{Name: name, Value: v}, nil
 }
+
+// UnmarshalXML reads a blank date as absent. A date that ParseDate rejects is
+// left zero rather than failing the whole feed, and Validate reports it.
+func (pd *Date) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var s string
+	if err := d.DecodeElement(&s, &start); err != nil {
+		return err
+	}
+	if strings.TrimSpace(s) == "" {
+		return errAbsent
+	}
+	t, _, _ := ParseDate(s)
+	*pd = Date(t)
+	return nil
+}
+
+// UnmarshalXMLAttr leaves the date zero if the attribute is blank or cannot
+// be parsed.
+func (pd *Date) UnmarshalXMLAttr(attr xml.Attr) error {
+	t, _, _ := ParseDate(attr.Value)
+	*pd = Date(t)
+	return nil
+}
@@ -1,12 +1,16 @@
 package types_test
 
 import (
+	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/google/uuid"
 	"github.com/rssblue/types"
 )
@@ -47,7 +51,7 @@ func TestMarshal(t *testing.T) {
 							Subcategory: pointer(types.ITunesSubcategory("Documentary")),
 						},
 					},
-					ITunesExplicit: pointer(true),
+					ITunesExplicit: pointer(types.ITunesExplicit(true)),
 					ITunesAuthor:   pointer("Jane Doe"),
 					Link:           pointer("https://example.com"),
 					AtomLink: &types.AtomLink{
@@ -59,7 +63,7 @@ func TestMarshal(t *testing.T) {
 						Name:  "Jane Doe",
 						Email: "jane@example.com",
 					},
-					ITunesType: pointer("episodic"),
+					ITunesType: pointer(types.ITunesTypeEpisodic),
 					Copyright:  pointer("© RSS Blue"),
 					PodcastLicense: &types.PodcastLicense{
 						Value: "ARR",
@@ -190,7 +194,7 @@ func TestMarshal(t *testing.T) {
 								Encoded: "This is a simple episode & its description.",
 								IsCDATA: false,
 							},
-							ITunesEpisodeType: pointer("full"),
+							ITunesEpisodeType: pointer(types.ITunesEpisodeTypeFull),
 							ITunesDuration:    pointer(types.ITunesDuration(10 * time.Minute)),
 							PSCChapters: &types.PSCChapters{
 								Version: "1.2",
@@ -251,8 +255,8 @@ func TestMarshal(t *testing.T) {
 							ITunesImage: &types.ITunesImage{
 								URL: "https://rssblue.com/@bookworm-podcast/hello-again/cover-art.png",
 							},
-							ITunesEpisodeType: pointer("full"),
-							ITunesExplicit:    pointer(false),
+							ITunesEpisodeType: pointer(types.ITunesEpisodeTypeFull),
+							ITunesExplicit:    pointer(types.ITunesExplicit(false)),
 							PodcastTranscripts: []types.PodcastTranscript{
 								{
 									URL:      "https://rssblue.com/@bookworm-podcast/hello-again/transcript.vtt",
@@ -369,8 +373,8 @@ func TestMarshal(t *testing.T) {
 								Description: "This is my <em>first</em> episode!",
 								IsCDATA:     true,
 							},
-							ITunesExplicit:    pointer(true),
-							ITunesEpisodeType: pointer("full"),
+							ITunesExplicit:    pointer(types.ITunesExplicit(true)),
+							ITunesEpisodeType: pointer(types.ITunesEpisodeTypeFull),
 							PodcastTranscripts: []types.PodcastTranscript{
 								{
 									URL:      "https://rssblue.com/@bookworm-podcast/hello-world/transcript.srt",
@@ -573,7 +577,7 @@ func TestMarshal(t *testing.T) {
 						Name:  "John Doe",
 						Email: "john@example.com",
 					},
-					ITunesType: pointer("serial"),
+					ITunesType: pointer(types.ITunesTypeSerial),
 					PodcastLocation: &types.PodcastLocation{
 						OSM: &types.PodcastOSM{
 							Type:      'R',
@@ -606,6 +610,205 @@ func TestMarshal(t *testing.T) {
     <podcast:location osm="R113314">Austin, TX</podcast:location>
     <podcast:medium>music</podcast:medium>
   </channel>
+</rss>`,
+		},
+		{
+			unmarshalled: types.RSS{
+				Channel: types.Channel{
+					Title: pointer("Scripting News"),
+					Link:  pointer("http://www.scripting.com/"),
+					Description: &types.Description{
+						Description: "A weblog about scripting and stuff like that.",
+					},
+					Categories: []types.Category{
+						{Category: "1765", Domain: pointer("Syndic8")},
+						{Category: "Weblogs"},
+					},
+					Cloud: &types.Cloud{
+						Domain:            "rpc.sys.com",
+						Port:              80,
+						Path:              "/RPC2",
+						RegisterProcedure: "pingMe",
+						Protocol:          types.CloudProtocolSOAP,
+					},
+					Docs: pointer("https://www.rssboard.org/rss-specification"),
+					Image: &types.Image{
+						URL:    "http://www.scripting.com/gifs/tinyScriptingNews.gif",
+						Title:  "Scripting News",
+						Link:   "http://www.scripting.com/",
+						Width:  pointer(88),
+						Height: pointer(31),
+					},
+					ManagingEditor: pointer("dave@example.com (Dave Winer)"),
+					PubDate:        pointer(types.Date(time.Date(2023, time.October, 30, 9, 0, 0, 0, time.UTC))),
+					Rating:         pointer(`(PICS-1.1 "http://www.rsac.org/ratingsv01.html" l by "webmaster@example.com" on "2007.01.29T10:09-0800" r (n 0 s 0 v 0 l 0))`),
+					SkipDays:       &types.SkipDays{Days: []string{"Saturday", "Sunday"}},
+					SkipHours:      &types.SkipHours{Hours: []int{0, 1, 2}},
+					TTL:            pointer(60),
+					TextInput: &types.TextInput{
+						Title:       "Search",
+						Description: "Search the archives",
+						Name:        "q",
+						Link:        "http://www.scripting.com/search",
+					},
+					WebMaster: pointer("webmaster@example.com (Webmaster)"),
+					Items: []types.Item{
+						{
+							Author: pointer("dave@example.com (Dave Winer)"),
+							Categories: []types.Category{
+								{Category: "Scripting"},
+								{Category: "Grateful Dead", Domain: pointer("http://www.example.com/cgi-bin/browse.pl")},
+							},
+							Comments: pointer("http://www.scripting.com/comments/venice"),
+							Link:     pointer("http://www.scripting.com/venice"),
+							Source: &types.Source{
+								URL:   "http://www.quotationspage.com/data/qotd.rss",
+								Title: "Quotes of the Day",
+							},
+							Title: pointer("Venice Film Festival"),
+						},
+					},
+				},
+			},
+			marshalled: `<rss version="2.0">
+  <channel>
+    <category domain="Syndic8">1765</category>
+    <category>Weblogs</category>
+    <cloud domain="rpc.sys.com" port="80" path="/RPC2" registerProcedure="pingMe" protocol="soap"></cloud>
+    <description>A weblog about scripting and stuff like that.</description>
+    <docs>https://www.rssboard.org/rss-specification</docs>
+    <image>
+      <url>http://www.scripting.com/gifs/tinyScriptingNews.gif</url>
+      <title>Scripting News</title>
+      <link>http://www.scripting.com/</link>
+      <width>88</width>
+      <height>31</height>
+    </image>
+    <link>http://www.scripting.com/</link>
+    <managingEditor>dave@example.com (Dave Winer)</managingEditor>
+    <pubDate>Mon, 30 Oct 2023 09:00:00 GMT</pubDate>
+    <rating>(PICS-1.1 &#34;http://www.rsac.org/ratingsv01.html&#34; l by &#34;webmaster@example.com&#34; on &#34;2007.01.29T10:09-0800&#34; r (n 0 s 0 v 0 l 0))</rating>
+    <skipDays>
+      <day>Saturday</day>
+      <day>Sunday</day>
+    </skipDays>
+    <skipHours>
+      <hour>0</hour>
+      <hour>1</hour>
+      <hour>2</hour>
+    </skipHours>
+    <ttl>60</ttl>
+    <textInput>
+      <title>Search</title>
+      <description>Search the archives</description>
+      <name>q</name>
+      <link>http://www.scripting.com/search</link>
+    </textInput>
+    <title>Scripting News</title>
+    <webMaster>webmaster@example.com (Webmaster)</webMaster>
+    <item>
+      <author>dave@example.com (Dave Winer)</author>
+      <category>Scripting</category>
+      <category domain="http://www.example.com/cgi-bin/browse.pl">Grateful Dead</category>
+      <comments>http://www.scripting.com/comments/venice</comments>
+      <link>http://www.scripting.com/venice</link>
+      <source url="http://www.quotationspage.com/data/qotd.rss">Quotes of the Day</source>
+      <title>Venice Film Festival</title>
+    </item>
+  </channel>
+</rss>`,
+		},
+		{
+			unmarshalled: types.RSS{
+				NamespaceGooglePlay: true,
+				Channel: types.Channel{
+					Title:                 pointer("Bookworm Podcast"),
+					GooglePlayAuthor:      pointer("Jane Doe"),
+					GooglePlayBlock:       pointer(types.GooglePlayBlock(false)),
+					GooglePlayCategories:  []types.GooglePlayCategory{types.GooglePlayCategoryArts, types.GooglePlayCategorySocietyAndCulture},
+					GooglePlayDescription: pointer("Podcast about books."),
+					GooglePlayEmail:       pointer("jane@example.com"),
+					GooglePlayExplicit:    pointer(types.GooglePlayExplicit(false)),
+					GooglePlayImage:       &types.GooglePlayImage{URL: "https://example.com/cover-art.png"},
+					GooglePlayNewFeedURL:  pointer("https://example.com/new-feed"),
+					GooglePlayOwner:       pointer("jane@example.com"),
+					Items: []types.Item{
+						{
+							Title:                 pointer("Hello World"),
+							GooglePlayAuthor:      pointer("John Doe"),
+							GooglePlayBlock:       pointer(types.GooglePlayBlock(true)),
+							GooglePlayDescription: pointer("First episode."),
+							GooglePlayExplicit:    pointer(types.GooglePlayExplicit(true)),
+							GooglePlayImage:       &types.GooglePlayImage{URL: "https://example.com/hello-world.png"},
+						},
+					},
+				},
+			},
+			marshalled: `<rss version="2.0" xmlns:googleplay="http://www.google.com/schemas/play-podcasts/1.0">
+  <channel>
+    <title>Bookworm Podcast</title>
+    <googleplay:author>Jane Doe</googleplay:author>
+    <googleplay:block>no</googleplay:block>
+    <googleplay:category text="Arts"></googleplay:category>
+    <googleplay:category text="Society &amp; Culture"></googleplay:category>
+    <googleplay:description>Podcast about books.</googleplay:description>
+    <googleplay:email>jane@example.com</googleplay:email>
+    <googleplay:explicit>no</googleplay:explicit>
+    <googleplay:image href="https://example.com/cover-art.png"></googleplay:image>
+    <googleplay:new-feed-url>https://example.com/new-feed</googleplay:new-feed-url>
+    <googleplay:owner>jane@example.com</googleplay:owner>
+    <item>
+      <title>Hello World</title>
+      <googleplay:author>John Doe</googleplay:author>
+      <googleplay:block>yes</googleplay:block>
+      <googleplay:description>First episode.</googleplay:description>
+      <googleplay:explicit>yes</googleplay:explicit>
+      <googleplay:image href="https://example.com/hello-world.png"></googleplay:image>
+    </item>
+  </channel>
+</rss>`,
+		},
+		{
+			unmarshalled: types.RSS{
+				NamespaceITunes: true,
+				Channel: types.Channel{
+					Title:          pointer("Bookworm Podcast"),
+					ITunesBlock:    pointer(types.ITunesBlock(false)),
+					ITunesComplete: pointer(types.ITunesComplete(true)),
+					ITunesKeywords: pointer("books,reading,literature"),
+					ITunesSubtitle: pointer("Books, read aloud"),
+					ITunesSummary:  pointer("Podcast about books."),
+					ITunesTitle:    pointer("Bookworm"),
+					Items: []types.Item{
+						{
+							Title:          pointer("Hello World"),
+							ITunesAuthor:   pointer("John Doe"),
+							ITunesBlock:    pointer(types.ITunesBlock(true)),
+							ITunesSubtitle: pointer("The first one"),
+							ITunesSummary:  pointer("First episode."),
+							ITunesTitle:    pointer("Hello"),
+						},
+					},
+				},
+			},
+			marshalled: `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+  <channel>
+    <title>Bookworm Podcast</title>
+    <itunes:block>No</itunes:block>
+    <itunes:complete>Yes</itunes:complete>
+    <itunes:keywords>books,reading,literature</itunes:keywords>
+    <itunes:subtitle>Books, read aloud</itunes:subtitle>
+    <itunes:summary>Podcast about books.</itunes:summary>
+    <itunes:title>Bookworm</itunes:title>
+    <item>
+      <title>Hello World</title>
+      <itunes:author>John Doe</itunes:author>
+      <itunes:block>Yes</itunes:block>
+      <itunes:subtitle>The first one</itunes:subtitle>
+      <itunes:summary>First episode.</itunes:summary>
+      <itunes:title>Hello</itunes:title>
+    </item>
+  </channel>
 </rss>`,
 		},
 	}
@@ -620,9 +823,1607 @@ func TestMarshal(t *testing.T) {
 		if diff != "" {
 			t.Errorf("%d: mismatch (-want +got):\n%s", i, diff)
 		}
+
+		// Round trip
+		var unmarshalled types.RSS
+		err = xml.Unmarshal(marshalled, &unmarshalled)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		remarshalled, err := xml.MarshalIndent(&unmarshalled, "", "  ")
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		diff = cmp.Diff(test.marshalled, string(remarshalled))
+		if diff != "" {
+			t.Errorf("%d: round trip mismatch (-want +got):\n%s", i, diff)
+		}
 	}
 }
 
-func pointer[T any](v T) *T {
-	return &v
+func TestUnmarshal(t *testing.T) {
+	tests := []struct {
+		marshalled   string
+		unmarshalled types.RSS
+	}{
+		{
+			marshalled: `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0" xmlns:psc="http://podlove.org/simple-chapters">
+  <channel>
+    <title>Bookworm Podcast</title>
+    <description>
+      <![CDATA[<strong>Description</strong>]]>
+    </description>
+    <lastBuildDate>Tue, 31 Oct 2023 11:00:00 +0000</lastBuildDate>
+    <itunes:category text="Society &amp; Culture">
+      <itunes:category text="Documentary"/>
+    </itunes:category>
+    <itunes:owner>
+      <itunes:name>Jane Doe</itunes:name>
+      <itunes:email>jane@example.com</itunes:email>
+    </itunes:owner>
+    <podcast:locked owner="jane@example.com">yes</podcast:locked>
+    <podcast:location geo="geo:30.2672,97.7431,150;u=350" osm="W5013364#2">Austin, TX</podcast:location>
+    <item>
+      <title>Hello World</title>
+      <description>Plain &amp; simple.</description>
+      <content:encoded><![CDATA[<p>Hello</p>]]></content:encoded>
+      <itunes:duration>671</itunes:duration>
+      <podcast:soundbite startTime="1234.5" duration="42.25">Why the Podcast Namespace Matters</podcast:soundbite>
+      <podcast:trailer pubdate="Thu, 01 Apr 2021 08:00:00 GMT" url="https://example.org/trailers/teaser">Coming Soon</podcast:trailer>
+      <psc:chapters version="1.2">
+        <psc:chapter start="00:00" title="Introduction"/>
+        <psc:chapter start="01:03:07.500" title="Break" href="https://example.com/break" image="https://example.com/break.jpg"/>
+      </psc:chapters>
+    </item>
+  </channel>
+</rss>`,
+			unmarshalled: types.RSS{
+				Version:          "2.0",
+				NamespaceContent: true,
+				NamespaceITunes:  true,
+				NamespacePodcast: true,
+				NamespacePSC:     true,
+				Channel: types.Channel{
+					Title: pointer("Bookworm Podcast"),
+					Description: &types.Description{
+						Description: "\n      <strong>Description</strong>\n    ",
+						IsCDATA:     true,
+					},
+					LastBuildDate: pointer(types.Date(time.Date(2023, time.October, 31, 11, 0, 0, 0, time.UTC))),
+					ITunesCategories: []types.ITunesCategory{
+						{
+							Category:    "Society & Culture",
+							Subcategory: pointer(types.ITunesSubcategory("Documentary")),
+						},
+					},
+					ITunesOwner: &types.ITunesOwner{
+						Name:  "Jane Doe",
+						Email: "jane@example.com",
+					},
+					PodcastLocked: &types.PodcastLocked{
+						Owner:    pointer("jane@example.com"),
+						IsLocked: true,
+					},
+					PodcastLocation: &types.PodcastLocation{
+						Geo: &types.PodcastGeo{
+							Latitude:    30.2672,
+							Longitude:   97.7431,
+							Altitude:    pointer(150.0),
+							Uncertainty: pointer(350.0),
+						},
+						OSM: &types.PodcastOSM{
+							Type:      'W',
+							FeatureID: 5013364,
+							Revision:  pointer[uint](2),
+						},
+						Location: "Austin, TX",
+					},
+					Items: []types.Item{
+						{
+							Title: pointer("Hello World"),
+							Description: &types.Description{
+								Description: "Plain & simple.",
+							},
+							ContentEncoded: &types.ContentEncoded{
+								Encoded: "<p>Hello</p>",
+								IsCDATA: true,
+							},
+							ITunesDuration: pointer(types.ITunesDuration(671 * time.Second)),
+							PodcastSoundbites: []types.PodcastSoundbite{
+								{
+									StartTime: 20*types.Minute + 34*types.Second + 500*types.Millisecond,
+									Duration:  42*types.Second + 250*types.Millisecond,
+									Title:     pointer("Why the Podcast Namespace Matters"),
+								},
+							},
+							PSCChapters: &types.PSCChapters{
+								Version: "1.2",
+								Chapters: []types.PSCChapter{
+									{
+										Start: 0,
+										Title: "Introduction",
+									},
+									{
+										Start: time.Hour + 3*time.Minute + 7*time.Second + 500*time.Millisecond,
+										Title: "Break",
+										Href:  mustParseURL("https://example.com/break"),
+										Image: mustParseURL("https://example.com/break.jpg"),
+									},
+								},
+							},
+							// The trailer belongs in the channel.
+							Extensions: []types.Extension{
+								{
+									XMLName: xml.Name{Local: "podcast:trailer"},
+									Attrs: []xml.Attr{
+										{Name: xml.Name{Local: "pubdate"}, Value: "Thu, 01 Apr 2021 08:00:00 GMT"},
+										{Name: xml.Name{Local: "url"}, Value: "https://example.org/trailers/teaser"},
+									},
+									InnerXML: "Coming Soon",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			marshalled: `<rss version="2.0" xmlns:iTunes="http://www.itunes.com/DTDs/Podcast-1.0.dtd" xmlns:pod="https://podcastindex.org/namespace/1.0" xmlns:p20="https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md">
+  <channel>
+    <title>World Explorer Podcast</title>
+    <iTunes:author>John Doe</iTunes:author>
+    <iTunes:block>true</iTunes:block>
+    <iTunes:complete> yes </iTunes:complete>
+    <iTunes:type>Serial</iTunes:type>
+    <pod:guid>96b952d9-06b2-5489-a3f3-d371473121fa</pod:guid>
+    <p20:medium>music</p20:medium>
+    <item>
+      <title>Episode</title>
+      <itunes:episode>3</itunes:episode>
+      <itunes:episodeType>FULL</itunes:episodeType>
+      <pod:person role="guest">Jane Doe</pod:person>
+      <transcript xmlns="https://podcastindex.org/namespace/1.0" url="https://example.com/transcript.srt" type="application/x-subrip"/>
+    </item>
+  </channel>
+</rss>`,
+			unmarshalled: types.RSS{
+				Version:          "2.0",
+				NamespaceITunes:  true,
+				NamespacePodcast: true,
+				ExtensionAttrs: []xml.Attr{
+					{Name: xml.Name{Local: "xmlns:iTunes"}, Value: "http://www.itunes.com/DTDs/Podcast-1.0.dtd"},
+					{Name: xml.Name{Local: "xmlns:pod"}, Value: "https://podcastindex.org/namespace/1.0"},
+					{Name: xml.Name{Local: "xmlns:p20"}, Value: "https://github.com/Podcastindex-org/podcast-namespace/blob/main/docs/1.0.md"},
+				},
+				Channel: types.Channel{
+					Title:          pointer("World Explorer Podcast"),
+					ITunesAuthor:   pointer("John Doe"),
+					ITunesBlock:    pointer(types.ITunesBlock(false)),
+					ITunesComplete: pointer(types.ITunesComplete(true)),
+					ITunesType:     pointer(types.ITunesTypeSerial),
+					PodcastGUID:    pointer(types.PodcastGUID("96b952d9-06b2-5489-a3f3-d371473121fa")),
+					PodcastMedium:  &types.PodcastMediumMusic,
+					Items: []types.Item{
+						{
+							Title:               pointer("Episode"),
+							ITunesEpisodeNumber: pointer[int64](3),
+							ITunesEpisodeType:   pointer(types.ITunesEpisodeTypeFull),
+							PodcastPersons: []types.PodcastPerson{
+								{
+									Name: "Jane Doe",
+									Role: pointer("guest"),
+								},
+							},
+							PodcastTranscripts: []types.PodcastTranscript{
+								{
+									URL:      "https://example.com/transcript.srt",
+									Mimetype: "application/x-subrip",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		var unmarshalled types.RSS
+		err := xml.Unmarshal([]byte(test.marshalled), &unmarshalled)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		diff := cmp.Diff(test.unmarshalled, unmarshalled, cmpOptions...)
+		if diff != "" {
+			t.Errorf("%d: mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestEncoderDateStyle(t *testing.T) {
+	newYork := time.FixedZone("EST", -5*3600)
+	channel := types.Channel{
+		LastBuildDate: pointer(types.Date(time.Date(2023, time.October, 31, 7, 0, 0, 0, newYork))),
+		PodcastTrailers: []types.PodcastTrailer{
+			{
+				Title:   "Coming Soon",
+				PubDate: types.Date(time.Date(2023, time.October, 31, 21, 30, 0, 0, newYork)),
+				URL:     "https://example.org/trailers/teaser",
+			},
+		},
+		Items: []types.Item{
+			{
+				PubDate: pointer(types.Date(time.Date(2023, time.October, 30, 22, 0, 0, 0, newYork))),
+			},
+		},
+	}
+
+	tests := []struct {
+		dateStyle  types.DateStyle
+		marshalled string
+	}{
+		{
+			dateStyle: types.DateStyleGMT,
+			marshalled: `<channel>
+  <lastBuildDate>Tue, 31 Oct 2023 12:00:00 GMT</lastBuildDate>
+  <podcast:trailer pubdate="Wed, 01 Nov 2023 02:30:00 GMT" url="https://example.org/trailers/teaser">Coming Soon</podcast:trailer>
+  <item>
+    <pubDate>Tue, 31 Oct 2023 03:00:00 GMT</pubDate>
+  </item>
+</channel>`,
+		},
+		{
+			dateStyle: types.DateStyleOffset,
+			marshalled: `<channel>
+  <lastBuildDate>Tue, 31 Oct 2023 07:00:00 -0500</lastBuildDate>
+  <podcast:trailer pubdate="Tue, 31 Oct 2023 21:30:00 -0500" url="https://example.org/trailers/teaser">Coming Soon</podcast:trailer>
+  <item>
+    <pubDate>Mon, 30 Oct 2023 22:00:00 -0500</pubDate>
+  </item>
+</channel>`,
+		},
+	}
+
+	for i, test := range tests {
+		var b strings.Builder
+		enc := types.NewEncoder(&b)
+		enc.Indent("", "  ")
+		enc.DateStyle = test.dateStyle
+		err := enc.Encode(&channel)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		diff := cmp.Diff(test.marshalled, b.String())
+		if diff != "" {
+			t.Errorf("%d: mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	// Encoding without an Encoder uses the default style.
+	marshalled, err := xml.MarshalIndent(&channel, "", "  ")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	diff := cmp.Diff(tests[0].marshalled, string(marshalled))
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	est := time.FixedZone("EST", -5*3600)
+	tests := []struct {
+		input  string
+		time   time.Time
+		issues []types.DateIssue
+	}{
+		{
+			input: "Tue, 31 Oct 2023 11:00:00 GMT",
+			time:  time.Date(2023, time.October, 31, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "Tue, 31 Oct 2023 06:00:00 -0500",
+			time:  time.Date(2023, time.October, 31, 11, 0, 0, 0, time.UTC),
+		},
+		{
+			input: "Tue, 31 Oct 2023 06:00:00 EST",
+			time:  time.Date(2023, time.October, 31, 6, 0, 0, 0, est),
+		},
+		{
+			input:  "31 Oct 2023 04:00:00 PDT",
+			time:   time.Date(2023, time.October, 31, 11, 0, 0, 0, time.UTC),
+			issues: []types.DateIssue{types.DateIssueMissingWeekday},
+		},
+		{
+			input:  "Sun, 1 Oct 23 12:30 +0000",
+			time:   time.Date(2023, time.October, 1, 12, 30, 0, 0, time.UTC),
+			issues: []types.DateIssue{types.DateIssueSingleDigitDay, types.DateIssueTwoDigitYear, types.DateIssueMissingSeconds},
+		},
+		{
+			input:  "Monday, 01 October 2023 12:30:00 CET",
+			time:   time.Date(2023, time.October, 1, 11, 30, 0, 0, time.UTC),
+			issues: []types.DateIssue{types.DateIssueLongName, types.DateIssueNonstandardZone, types.DateIssueWrongWeekday},
+		},
+		{
+			input:  "Sun, 01 Oct 2023 12:30:00",
+			time:   time.Date(2023, time.October, 1, 12, 30, 0, 0, time.UTC),
+			issues: []types.DateIssue{types.DateIssueMissingZone},
+		},
+		{
+			input:  "2023-10-01T14:30:00+02:00",
+			time:   time.Date(2023, time.October, 1, 12, 30, 0, 0, time.UTC),
+			issues: []types.DateIssue{types.DateIssueISO8601},
+		},
+		{
+			input:  "2023-10-01",
+			time:   time.Date(2023, time.October, 1, 0, 0, 0, 0, time.UTC),
+			issues: []types.DateIssue{types.DateIssueISO8601, types.DateIssueMissingZone},
+		},
+	}
+
+	for i, test := range tests {
+		parsed, issues, err := types.ParseDate(test.input)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		if !parsed.Equal(test.time) {
+			t.Errorf("%d: expected %v, got %v", i, test.time, parsed)
+		}
+		diff := cmp.Diff(test.issues, issues)
+		if diff != "" {
+			t.Errorf("%d: issues mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+
+	for _, input := range []string{"", "yesterday", "Tue, 31 Foo 2023 11:00:00 GMT", "Tue, 31 Feb 2023 11:00:00 GMT", "Tue, 31 Oct 2023 25:00:00 GMT"} {
+		if _, _, err := types.ParseDate(input); err == nil {
+			t.Errorf("expected error for \"%s\"", input)
+		}
+	}
+}
+
+func TestUnmarshalMalformedDates(t *testing.T) {
+	feed := `<rss version="2.0">
+  <channel>
+    <title>Bookworm Podcast</title>
+    <lastBuildDate>yesterday</lastBuildDate>
+    <pubDate></pubDate>
+    <item>
+      <title>Hello World</title>
+      <pubDate> </pubDate>
+    </item>
+    <item>
+      <title>Hello Again</title>
+      <pubDate>Tue, 31 Feb 2023 11:00:00 GMT</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	var rss types.RSS
+	if err := xml.Unmarshal([]byte(feed), &rss); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rss.Channel.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(rss.Channel.Items))
+	}
+	if rss.Channel.PubDate != nil || rss.Channel.Items[0].PubDate != nil {
+		t.Errorf("expected blank dates to be absent")
+	}
+
+	var errs []types.ValidationError
+	for _, err := range rss.Validate() {
+		if err.Rule == "date-invalid" {
+			errs = append(errs, err)
+		}
+	}
+	expected := []types.ValidationError{
+		{
+			Severity: types.SeverityError,
+			Rule:     "date-invalid",
+			Path:     "channel.lastBuildDate",
+			Message:  "date could not be parsed",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "date-invalid",
+			Path:     "channel.items[1].pubDate",
+			Message:  "date could not be parsed",
+		},
+	}
+	diff := cmp.Diff(expected, errs)
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// cmpOptions compare decoded values by meaning: names filled in by the
+// decoder are ignored and times are compared as instants.
+var cmpOptions = []cmp.Option{
+	cmpopts.IgnoreTypes(xml.Name{}),
+	cmp.Comparer(func(a, b types.Date) bool {
+		return time.Time(a).Equal(time.Time(b))
+	}),
+	cmp.Comparer(func(a, b time.Time) bool {
+		return a.Equal(b)
+	}),
+	cmp.Comparer(func(a, b *url.URL) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.String() == b.String()
+	}),
+}
+
+func mustParseURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func pointer[T any](v T) *T {
+	return &v
+}
+
+func TestNewPodcastGUID(t *testing.T) {
+	tests := []struct {
+		feedURL string
+		guid    types.PodcastGUID
+	}{
+		{
+			feedURL: "https://mp3s.nashownotes.com/pc20rss.xml",
+			guid:    "917393e3-1b1e-5cef-ace4-edaa54e1f810",
+		},
+		{
+			feedURL: "http://mp3s.nashownotes.com/pc20rss.xml//",
+			guid:    "917393e3-1b1e-5cef-ace4-edaa54e1f810",
+		},
+		{
+			feedURL: "mp3s.nashownotes.com/pc20rss.xml",
+			guid:    "917393e3-1b1e-5cef-ace4-edaa54e1f810",
+		},
+	}
+
+	for _, test := range tests {
+		guid := types.NewPodcastGUID(test.feedURL)
+		if guid != test.guid {
+			t.Errorf("%s: expected %s, got %s", test.feedURL, test.guid, guid)
+		}
+		if !guid.IsValid() {
+			t.Errorf("%s: expected %s to be valid", test.feedURL, guid)
+		}
+	}
+
+	for _, guid := range []types.PodcastGUID{"", "not-a-uuid", "917393e3-1b1e-4cef-ace4-edaa54e1f810"} {
+		if guid.IsValid() {
+			t.Errorf("expected %q to be invalid", guid)
+		}
+	}
+
+	// A missing feed GUID of a remote item is derived from its feed URL.
+	remoteItem := types.PodcastRemoteItem{
+		FeedURL: pointer("https://mp3s.nashownotes.com/pc20rss.xml"),
+	}
+	marshalled, err := xml.Marshal(&remoteItem)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	expected := `<podcast:remoteItem feedGuid="917393e3-1b1e-5cef-ace4-edaa54e1f810" feedUrl="https://mp3s.nashownotes.com/pc20rss.xml"></podcast:remoteItem>`
+	diff := cmp.Diff(expected, string(marshalled))
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestDeclareNamespaces(t *testing.T) {
+	rss := types.RSS{
+		NamespaceAtom: true,
+		Channel: types.Channel{
+			Title:       pointer("Bookworm Podcast"),
+			ITunesOwner: &types.ITunesOwner{Name: "Jane Doe", Email: "jane@example.com"},
+			Items: []types.Item{
+				{
+					Title: pointer("Hello World"),
+					PSCChapters: &types.PSCChapters{
+						Chapters: []types.PSCChapter{
+							{Start: 0, Title: "Introduction"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	rss.DeclareNamespaces()
+	expected := types.RSS{
+		NamespaceITunes: true,
+		NamespacePSC:    true,
+		Channel:         rss.Channel,
+	}
+	diff := cmp.Diff(expected, rss, cmpOptions...)
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// Fields set afterwards force a declaration.
+	rss.NamespaceAtom = true
+	output, err := xml.Marshal(&rss)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(output), ` xmlns:atom="http://www.w3.org/2005/Atom"`) {
+		t.Errorf("expected an atom declaration, got %s", output)
+	}
+}
+
+func TestExtensions(t *testing.T) {
+	feed := `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0" xmlns:media="http://search.yahoo.com/mrss/" xmlns:dc="http://purl.org/dc/elements/1.1/" xml:lang="en">
+  <channel>
+    <title>Bookworm Podcast</title>
+    <dc:creator>Jane Doe</dc:creator>
+    <media:rating scheme="urn:simple">nonadult</media:rating>
+    <podcast:podroll>
+      <podcast:remoteItem feedGuid="917393e3-1b1e-5cef-ace4-edaa54e1f810"/>
+    </podcast:podroll>
+    <item media:region="eu">
+      <title>Hello World</title>
+      <media:group>
+        <media:content url="https://example.com/hello-world.mp3" fileSize="1024"/>
+        <media:content url="https://example.com/hello-world.ogg" fileSize="2048"/>
+      </media:group>
+      <vendor:tag xmlns:vendor="https://example.com/vendor" vendor:id="42">Custom</vendor:tag>
+    </item>
+  </channel>
+</rss>`
+
+	var rss types.RSS
+	err := xml.Unmarshal([]byte(feed), &rss)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rss.Channel.Items[0].Title = pointer("Hello, World")
+
+	expected := `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd" xmlns:podcast="https://podcastindex.org/namespace/1.0" xmlns:media="http://search.yahoo.com/mrss/" xmlns:dc="http://purl.org/dc/elements/1.1/" xml:lang="en">
+  <channel>
+    <title>Bookworm Podcast</title>
+    <dc:creator>Jane Doe</dc:creator>
+    <media:rating scheme="urn:simple">nonadult</media:rating>
+    <podcast:podroll>
+      <podcast:remoteItem feedGuid="917393e3-1b1e-5cef-ace4-edaa54e1f810"/>
+    </podcast:podroll>
+    <item media:region="eu">
+      <title>Hello, World</title>
+      <media:group>
+        <media:content url="https://example.com/hello-world.mp3" fileSize="1024"/>
+        <media:content url="https://example.com/hello-world.ogg" fileSize="2048"/>
+      </media:group>
+      <vendor:tag xmlns:vendor="https://example.com/vendor" vendor:id="42">Custom</vendor:tag>
+    </item>
+  </channel>
+</rss>`
+	marshalled, err := xml.MarshalIndent(&rss, "", "  ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	diff := cmp.Diff(expected, string(marshalled))
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestItemExtensions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    `<item xmlns:media="http://search.yahoo.com/mrss/"><title>Hello World</title><media:content url="https://example.com/hello-world.mp4"><media:title>Hello</media:title></media:content></item>`,
+			expected: `<item xmlns:media="http://search.yahoo.com/mrss/"><title>Hello World</title><media:content url="https://example.com/hello-world.mp4"><media:title>Hello</media:title></media:content></item>`,
+		},
+		{
+			input:    `<item><title>Hello World</title><media:content xmlns:media="http://search.yahoo.com/mrss/" url="https://example.com/hello-world.mp4"/></item>`,
+			expected: `<item><title>Hello World</title><media:content xmlns:media="http://search.yahoo.com/mrss/" url="https://example.com/hello-world.mp4"></media:content></item>`,
+		},
+		{
+			input:    `<item><title>Hello World</title><media:content url="https://example.com/hello-world.mp4"/></item>`,
+			expected: `<item><title>Hello World</title><media:content url="https://example.com/hello-world.mp4"></media:content></item>`,
+		},
+	}
+
+	for _, test := range tests {
+		var item types.Item
+		err := xml.Unmarshal([]byte(test.input), &item)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		marshalled, err := xml.Marshal(&item)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		diff := cmp.Diff(test.expected, string(marshalled))
+		if diff != "" {
+			t.Errorf("mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestMarshalValueRecipientFee(t *testing.T) {
+	recipient := types.PodcastValueRecipient{
+		Type:    "node",
+		Address: "02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52",
+		Split:   1,
+		Fee:     pointer(true),
+	}
+	output, err := xml.Marshal(&recipient)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(output), ` fee="true"`) || strings.Contains(string(output), ` bool=`) {
+		t.Errorf("expected a fee attribute, got %s", output)
+	}
+}
+
+func TestMarshalInvalidEnums(t *testing.T) {
+	values := []interface{}{
+		types.Channel{ITunesType: pointer(types.ITunesType("Episodic"))},
+		types.Item{ITunesEpisodeType: pointer(types.ITunesEpisodeType("bonuses"))},
+	}
+	for i, v := range values {
+		if _, err := xml.Marshal(v); err == nil {
+			t.Errorf("%d: expected an error", i)
+		}
+	}
+}
+
+func TestITunesCategories(t *testing.T) {
+	if n := len(types.ITunesCategoryNames()); n != 19 {
+		t.Errorf("expected 19 categories, got %d", n)
+	}
+
+	diff := cmp.Diff([]types.ITunesSubcategory{
+		types.ITunesSubcategoryComedyFiction,
+		types.ITunesSubcategoryDrama,
+		types.ITunesSubcategoryScienceFiction,
+	}, types.ITunesCategoryFiction.Subcategories())
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if subcategories := types.ITunesCategoryTrueCrime.Subcategories(); len(subcategories) != 0 {
+		t.Errorf("unexpected subcategories: %v", subcategories)
+	}
+
+	parent, ok := types.ITunesSubcategoryDocumentary.Parent()
+	if !ok || parent != types.ITunesCategorySocietyAndCulture {
+		t.Errorf("unexpected parent %q", parent)
+	}
+	if _, ok := types.ITunesSubcategory("Literature").Parent(); ok {
+		t.Errorf("expected no parent")
+	}
+
+	tests := []struct {
+		category types.ITunesCategory
+		isValid  bool
+	}{
+		{
+			category: types.ITunesCategory{Category: types.ITunesCategoryTechnology},
+			isValid:  true,
+		},
+		{
+			category: types.ITunesCategory{
+				Category:    types.ITunesCategorySocietyAndCulture,
+				Subcategory: pointer(types.ITunesSubcategoryDocumentary),
+			},
+			isValid: true,
+		},
+		{
+			category: types.ITunesCategory{
+				Category:    types.ITunesCategoryArts,
+				Subcategory: pointer(types.ITunesSubcategoryDocumentary),
+			},
+			isValid: false,
+		},
+		{
+			category: types.ITunesCategory{Category: "Literature"},
+			isValid:  false,
+		},
+	}
+	for i, test := range tests {
+		if isValid := test.category.IsValid(); isValid != test.isValid {
+			t.Errorf("%d: expected %t, got %t", i, test.isValid, isValid)
+		}
+	}
+
+	if s := types.ITunesCategorySocietyAndCulture.EscapedText(); s != "Society &amp; Culture" {
+		t.Errorf("unexpected escaped text %q", s)
+	}
+	if s := types.ITunesSubcategoryPetsAndAnimals.EscapedText(); s != "Pets &amp; Animals" {
+		t.Errorf("unexpected escaped text %q", s)
+	}
+}
+
+func TestEncoderExplicitStyle(t *testing.T) {
+	channel := types.Channel{
+		ITunesExplicit: pointer(types.ITunesExplicit(false)),
+		Items: []types.Item{
+			{
+				ITunesExplicit: pointer(types.ITunesExplicit(true)),
+			},
+		},
+	}
+
+	tests := []struct {
+		explicitStyle types.ExplicitStyle
+		marshalled    string
+	}{
+		{
+			explicitStyle: types.ExplicitStyleTrueFalse,
+			marshalled:    `<channel><itunes:explicit>false</itunes:explicit><item><itunes:explicit>true</itunes:explicit></item></channel>`,
+		},
+		{
+			explicitStyle: types.ExplicitStyleYesClean,
+			marshalled:    `<channel><itunes:explicit>clean</itunes:explicit><item><itunes:explicit>yes</itunes:explicit></item></channel>`,
+		},
+	}
+
+	for i, test := range tests {
+		var b strings.Builder
+		enc := types.NewEncoder(&b)
+		enc.ExplicitStyle = test.explicitStyle
+		err := enc.Encode(&channel)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", i, err)
+		}
+		diff := cmp.Diff(test.marshalled, b.String())
+		if diff != "" {
+			t.Errorf("%d: mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestUnmarshalITunesExplicit(t *testing.T) {
+	tests := []struct {
+		value    string
+		explicit types.ITunesExplicit
+	}{
+		{value: "true", explicit: true},
+		{value: "Yes", explicit: true},
+		{value: " explicit ", explicit: true},
+		{value: "false", explicit: false},
+		{value: "no", explicit: false},
+		{value: "Clean", explicit: false},
+	}
+
+	for _, test := range tests {
+		var explicit types.ITunesExplicit
+		err := xml.Unmarshal([]byte("<itunes:explicit>"+test.value+"</itunes:explicit>"), &explicit)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.value, err)
+		}
+		if explicit != test.explicit {
+			t.Errorf("%q: expected %t, got %t", test.value, test.explicit, explicit)
+		}
+	}
+
+	var explicit types.ITunesExplicit
+	if err := xml.Unmarshal([]byte("<itunes:explicit>maybe</itunes:explicit>"), &explicit); err == nil {
+		t.Errorf("expected an error")
+	}
+
+	// Within a feed, blank and unknown values are read as absent.
+	for _, value := range []string{"", "maybe"} {
+		input := `<item xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd"><title>Hello World</title><itunes:explicit>` + value + `</itunes:explicit></item>`
+		var item types.Item
+		err := xml.Unmarshal([]byte(input), &item)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", value, err)
+		}
+		if item.ITunesExplicit != nil {
+			t.Errorf("%q: expected no value, got %t", value, *item.ITunesExplicit)
+		}
+	}
+}
+
+func TestUnmarshalGooglePlayExplicit(t *testing.T) {
+	tests := map[string]*types.GooglePlayExplicit{
+		"Yes":   pointer(types.GooglePlayExplicit(true)),
+		"clean": pointer(types.GooglePlayExplicit(false)),
+		"":      nil,
+		"maybe": nil,
+	}
+	for value, expected := range tests {
+		input := `<item xmlns:googleplay="http://www.google.com/schemas/play-podcasts/1.0"><title>Hello World</title><googleplay:explicit>` + value + `</googleplay:explicit></item>`
+		var item types.Item
+		err := xml.Unmarshal([]byte(input), &item)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", value, err)
+		}
+		if diff := cmp.Diff(expected, item.GooglePlayExplicit); diff != "" {
+			t.Errorf("%q: mismatch (-want +got):\n%s", value, diff)
+		}
+	}
+}
+
+func TestITunesDuration(t *testing.T) {
+	tests := []struct {
+		value    string
+		duration time.Duration
+	}{
+		{value: "3723", duration: time.Hour + 2*time.Minute + 3*time.Second},
+		{value: "671.5", duration: 11*time.Minute + 11*time.Second + 500*time.Millisecond},
+		{value: "11:11", duration: 11*time.Minute + 11*time.Second},
+		{value: "90:00", duration: 90 * time.Minute},
+		{value: "01:02:03", duration: time.Hour + 2*time.Minute + 3*time.Second},
+		{value: " 1:02:03.25 ", duration: time.Hour + 2*time.Minute + 3*time.Second + 250*time.Millisecond},
+	}
+	for _, test := range tests {
+		var duration types.ITunesDuration
+		err := xml.Unmarshal([]byte("<itunes:duration>"+test.value+"</itunes:duration>"), &duration)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.value, err)
+		}
+		if time.Duration(duration) != test.duration {
+			t.Errorf("%q: expected %s, got %s", test.value, test.duration, time.Duration(duration))
+		}
+	}
+
+	// Malformed durations are kept for Validate to report.
+	for _, value := range []string{"-5", "1:2:3:4", "01:02:03:04", "01:60:00", "10:75", "1h", "NaN"} {
+		var duration types.ITunesDuration
+		err := xml.Unmarshal([]byte("<itunes:duration>"+value+"</itunes:duration>"), &duration)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", value, err)
+		}
+		if duration >= 0 {
+			t.Errorf("%q: expected a negative duration, got %s", value, time.Duration(duration))
+		}
+	}
+	if _, err := xml.Marshal(pointer(types.ITunesDuration(-time.Second))); err == nil {
+		t.Errorf("expected an error for a negative duration")
+	}
+
+	feed := `<rss version="2.0" xmlns:itunes="http://www.itunes.com/dtds/podcast-1.0.dtd">
+  <channel>
+    <title>Bookworm Podcast</title>
+    <item>
+      <title>Hello World</title>
+      <itunes:duration></itunes:duration>
+    </item>
+    <item>
+      <title>Hello Again</title>
+      <itunes:duration>01:02:03:04</itunes:duration>
+    </item>
+  </channel>
+</rss>`
+	var rss types.RSS
+	if err := xml.Unmarshal([]byte(feed), &rss); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rss.Channel.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(rss.Channel.Items))
+	}
+	if rss.Channel.Items[0].ITunesDuration != nil {
+		t.Errorf("expected a blank duration to be absent")
+	}
+	var errs []types.ValidationError
+	for _, err := range rss.Validate() {
+		if err.Rule == "itunes-duration-invalid" {
+			errs = append(errs, err)
+		}
+	}
+	expected := []types.ValidationError{
+		{
+			Severity: types.SeverityError,
+			Rule:     "itunes-duration-invalid",
+			Path:     "channel.items[1].itunes:duration",
+			Message:  "duration must be given as seconds, MM:SS or HH:MM:SS",
+		},
+	}
+	if diff := cmp.Diff(expected, errs); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// Encoding
+	item := types.Item{
+		ITunesDuration: pointer(types.ITunesDuration(26*time.Hour + 2*time.Minute + 3*time.Second + 900*time.Millisecond)),
+	}
+	styles := map[types.DurationStyle]string{
+		types.DurationStyleSeconds: `<item><itunes:duration>93723</itunes:duration></item>`,
+		types.DurationStyleHHMMSS:  `<item><itunes:duration>26:02:03</itunes:duration></item>`,
+	}
+	for style, expected := range styles {
+		var b strings.Builder
+		enc := types.NewEncoder(&b)
+		enc.DurationStyle = style
+		err := enc.Encode(&item)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", style, err)
+		}
+		diff := cmp.Diff(expected, b.String())
+		if diff != "" {
+			t.Errorf("%d: mismatch (-want +got):\n%s", style, diff)
+		}
+	}
+}
+
+func TestPSCChapterStart(t *testing.T) {
+	tests := []struct {
+		start    time.Duration
+		expected string
+	}{
+		{start: 0, expected: "00:00"},
+		{start: 59*time.Second + 999*time.Millisecond, expected: "00:59.999"},
+		{start: time.Hour + 3*time.Minute + 7*time.Second + 500*time.Millisecond, expected: "01:03:07.500"},
+		{start: 25*time.Hour + time.Millisecond, expected: "25:00:00.001"},
+		{start: 123*time.Hour + 59*time.Minute + 59*time.Second, expected: "123:59:59"},
+	}
+	for _, test := range tests {
+		chapter := types.PSCChapter{Start: test.start, Title: "Chapter"}
+		output, err := xml.Marshal(&chapter)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.start, err)
+			continue
+		}
+		expected := `<psc:chapter start="` + test.expected + `" title="Chapter"></psc:chapter>`
+		if diff := cmp.Diff(expected, string(output)); diff != "" {
+			t.Errorf("%s: mismatch (-want +got):\n%s", test.start, diff)
+		}
+
+		var decoded types.PSCChapter
+		if err := xml.Unmarshal(output, &decoded); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.start, err)
+		}
+		if decoded.Start != test.start {
+			t.Errorf("%s: expected %s, got %s", test.expected, test.start, decoded.Start)
+		}
+	}
+
+	lenient := map[string]time.Duration{
+		"3723.5":     time.Hour + 2*time.Minute + 3*time.Second + 500*time.Millisecond,
+		"90:00":      90 * time.Minute,
+		"1:02:03.4":  time.Hour + 2*time.Minute + 3*time.Second + 400*time.Millisecond,
+		"00:01.2345": time.Second + 235*time.Millisecond,
+	}
+	for value, start := range lenient {
+		var chapter types.PSCChapter
+		err := xml.Unmarshal([]byte(`<psc:chapter start="`+value+`" title=""/>`), &chapter)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", value, err)
+		}
+		if chapter.Start != start {
+			t.Errorf("%q: expected %s, got %s", value, start, chapter.Start)
+		}
+	}
+
+	for _, value := range []string{"", "-5", "1:2:3:4", "01:60:00", "10:75", "1:2", "1.", "1e3", "NaN"} {
+		var chapter types.PSCChapter
+		err := xml.Unmarshal([]byte(`<psc:chapter start="`+value+`" title=""/>`), &chapter)
+		if err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+
+	_, err := xml.Marshal(types.PSCChapter{Start: -time.Second})
+	if err == nil {
+		t.Errorf("expected an error for a negative start")
+	}
+}
+
+func TestJSONChapters(t *testing.T) {
+	chapters := types.JSONChapters{
+		Author:      pointer("John"),
+		Title:       pointer("Book Review: Moby-Dick"),
+		PodcastName: pointer("Bookworm Podcast"),
+		Chapters: []types.JSONChapter{
+			{
+				StartTime: 0,
+				Title:     pointer("Intro"),
+				ImageURL:  pointer("https://example.com/intro.png"),
+			},
+			{
+				StartTime: 90*types.Second + 500*types.Millisecond,
+				EndTime:   pointer(600 * types.Second),
+				Title:     pointer("Nantucket"),
+				URL:       pointer("https://en.wikipedia.org/wiki/Nantucket"),
+				Location: &types.JSONChapterLocation{
+					Name: "Nantucket",
+					Geo:  types.PodcastGeo{Latitude: 41.28, Longitude: -70.1},
+					OSM:  &types.PodcastOSM{Type: 'R', FeatureID: 2396248},
+				},
+			},
+			{
+				StartTime: 600 * types.Second,
+				ImageURL:  pointer("https://example.com/whale.png"),
+				TOC:       pointer(false),
+			},
+		},
+	}
+	expected := `{"version":"1.2.0","author":"John","title":"Book Review: Moby-Dick","podcastName":"Bookworm Podcast","chapters":[` +
+		`{"startTime":0,"title":"Intro","img":"https://example.com/intro.png"},` +
+		`{"startTime":90.5,"endTime":600,"title":"Nantucket","url":"https://en.wikipedia.org/wiki/Nantucket","location":{"name":"Nantucket","geo":"geo:41.28,-70.1","osm":"R2396248"}},` +
+		`{"startTime":600,"img":"https://example.com/whale.png","toc":false}]}`
+
+	output, err := json.Marshal(chapters)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, string(output)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	var decoded types.JSONChapters
+	if err := json.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	chapters.Version = types.JSONChaptersVersion
+	if diff := cmp.Diff(chapters, decoded); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	for _, input := range []string{
+		`{"version":"1.2.0","chapters":[{"startTime":"10"}]}`,
+		`{"version":"1.2.0","chapters":[{"startTime":0,"location":{"name":"Sea","geo":"41,70"}}]}`,
+	} {
+		if err := json.Unmarshal([]byte(input), &decoded); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+}
+
+func TestTranscript(t *testing.T) {
+	transcript := types.Transcript{
+		Version: types.TranscriptVersion,
+		Segments: []types.TranscriptSegment{
+			{Speaker: "Alice", StartTime: 0, EndTime: 2500 * types.Millisecond, Body: "Welcome to <Bookworm> & friends."},
+			{Speaker: "Bob", StartTime: 2500 * types.Millisecond, EndTime: 3723 * types.Second, Body: "Thanks!\nGlad to be here."},
+			{StartTime: 3723 * types.Second, EndTime: 3723 * types.Second, Body: "[music]"},
+		},
+	}
+	encodings := map[string]string{
+		types.MimetypeJSONTranscript: `{"version":"1.0.0","segments":[` +
+			`{"speaker":"Alice","startTime":0,"endTime":2.5,"body":"Welcome to \u003cBookworm\u003e \u0026 friends."},` +
+			`{"speaker":"Bob","startTime":2.5,"endTime":3723,"body":"Thanks!\nGlad to be here."},` +
+			`{"startTime":3723,"endTime":3723,"body":"[music]"}]}`,
+		types.MimetypeSRT: `1
+00:00:00,000 --> 00:00:02,500
+<v Alice>Welcome to <Bookworm> & friends.
+
+2
+00:00:02,500 --> 01:02:03,000
+<v Bob>Thanks!
+Glad to be here.
+
+3
+01:02:03,000 --> 01:02:03,000
+[music]
+`,
+		types.MimetypeWebVTT: `WEBVTT
+
+00:00:00.000 --> 00:00:02.500
+<v Alice>Welcome to &lt;Bookworm&gt; &amp; friends.
+
+00:00:02.500 --> 01:02:03.000
+<v Bob>Thanks!
+Glad to be here.
+
+01:02:03.000 --> 01:02:03.000
+[music]
+`,
+		types.MimetypeHTML: `<cite>Alice:</cite>
+<time>00:00:00.000</time>
+<p>Welcome to &lt;Bookworm&gt; &amp; friends.</p>
+<cite>Bob:</cite>
+<time>00:00:02.500</time>
+<p>Thanks!
+Glad to be here.</p>
+<cite></cite>
+<time>01:02:03.000</time>
+<p>[music]</p>
+`,
+	}
+	for _, mimetype := range types.TranscriptMimetypes {
+		output, err := transcript.Encode(mimetype)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", mimetype, err)
+			continue
+		}
+		if diff := cmp.Diff(encodings[mimetype], string(output)); diff != "" {
+			t.Errorf("%s: mismatch (-want +got):\n%s", mimetype, diff)
+		}
+
+		decoded, err := types.ParseTranscript(output, mimetype)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", mimetype, err)
+			continue
+		}
+		if diff := cmp.Diff(&transcript, decoded); diff != "" {
+			t.Errorf("%s: mismatch (-want +got):\n%s", mimetype, diff)
+		}
+	}
+
+	// Colons in bodies do not introduce speakers.
+	transcript.Segments = []types.TranscriptSegment{
+		{StartTime: 0, EndTime: types.Second, Body: "Note: the guest joined late"},
+		{Speaker: "Alice", StartTime: types.Second, EndTime: 2 * types.Second, Body: "He said: hi"},
+	}
+	decoded, err := types.ParseSRT(transcript.SRT())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(&transcript, decoded); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	input := "\ufeffWEBVTT - Book review\r\n\r\nNOTE This is a comment\r\n\r\nintro\r\n00:01.000 --> 00:04.250 align:start\r\n<v.loud Alice Smith><i>Hello</i>, Bob &amp; Carol!</v>\r\n"
+	decoded, err = types.ParseTranscript([]byte(input), "text/vtt; charset=utf-8")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := &types.Transcript{
+		Version: types.TranscriptVersion,
+		Segments: []types.TranscriptSegment{
+			{Speaker: "Alice Smith", StartTime: types.Second, EndTime: 4250 * types.Millisecond, Body: "Hello, Bob & Carol!"},
+		},
+	}
+	if diff := cmp.Diff(expected, decoded); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	invalid := map[string]string{
+		types.MimetypeSRT:    "1\n00:00:01,000 -> 00:00:02,000\nHello\n",
+		types.MimetypeWebVTT: "00:00:01.000 --> 00:00:02.000\nHello\n",
+		types.MimetypeHTML:   "<time>soon</time><p>Hello</p>",
+		"text/plain":         "Hello",
+	}
+	for mimetype, input := range invalid {
+		if _, err := types.ParseTranscript([]byte(input), mimetype); err == nil {
+			t.Errorf("%s: expected an error", mimetype)
+		}
+	}
+}
+
+func TestPodcastImages(t *testing.T) {
+	images := types.PodcastImages{
+		Images: []types.PodcastImage{
+			{URL: "https://example.com/images/ep1/pci_avatar-massive.jpg", Width: 1500},
+			{URL: "https://example.com/images/ep1/pci_avatar-middle.jpg", Width: 600},
+			{URL: "https://example.com/images/ep1/pci_avatar-small.jpg", Width: 300},
+			{URL: "https://example.com/images/ep1/pci_avatar-tiny.jpg", Width: 150},
+		},
+	}
+	expected := `<podcast:images srcset="https://example.com/images/ep1/pci_avatar-massive.jpg 1500w, https://example.com/images/ep1/pci_avatar-middle.jpg 600w, https://example.com/images/ep1/pci_avatar-small.jpg 300w, https://example.com/images/ep1/pci_avatar-tiny.jpg 150w"></podcast:images>`
+
+	output, err := xml.Marshal(&images)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, string(output)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	var decoded types.PodcastImages
+	if err := xml.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(images, decoded, cmpOptions...); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	widths := map[int]string{
+		0:    "https://example.com/images/ep1/pci_avatar-tiny.jpg",
+		300:  "https://example.com/images/ep1/pci_avatar-small.jpg",
+		301:  "https://example.com/images/ep1/pci_avatar-middle.jpg",
+		3000: "https://example.com/images/ep1/pci_avatar-massive.jpg",
+	}
+	for width, url := range widths {
+		if best := images.Best(width); best == nil || best.URL != url {
+			t.Errorf("%d: expected %s, got %v", width, url, best)
+		}
+	}
+	if best := (types.PodcastImages{}).Best(100); best != nil {
+		t.Errorf("expected no image, got %v", best)
+	}
+
+	srcsets := map[string][]types.PodcastImage{
+		"https://example.com/a,b.jpg 600w,https://example.com/c.jpg\t300w": {
+			{URL: "https://example.com/a,b.jpg", Width: 600},
+			{URL: "https://example.com/c.jpg", Width: 300},
+		},
+		" https://example.com/a.jpg, https://example.com/b.jpg 300w ": {
+			{URL: "https://example.com/a.jpg"},
+			{URL: "https://example.com/b.jpg", Width: 300},
+		},
+	}
+	for srcset, expected := range srcsets {
+		var decoded types.PodcastImages
+		err := xml.Unmarshal([]byte(`<podcast:images srcset="`+srcset+`"/>`), &decoded)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", srcset, err)
+		}
+		if diff := cmp.Diff(expected, decoded.Images); diff != "" {
+			t.Errorf("%q: mismatch (-want +got):\n%s", srcset, diff)
+		}
+	}
+
+	// Candidates without a valid width descriptor are kept for Validate to
+	// report.
+	for _, srcset := range []string{"https://example.com/a.jpg 2x", "https://example.com/a.jpg w", "https://example.com/a.jpg -300w", "https://example.com/a.jpg 300w 2x"} {
+		var item types.Item
+		err := xml.Unmarshal([]byte(`<item xmlns:podcast="https://podcastindex.org/namespace/1.0"><title>Hello World</title><podcast:images srcset="`+srcset+`"/></item>`), &item)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", srcset, err)
+			continue
+		}
+		if diff := cmp.Diff([]types.PodcastImage{{URL: "https://example.com/a.jpg"}}, item.PodcastImages.Images); diff != "" {
+			t.Errorf("%q: mismatch (-want +got):\n%s", srcset, diff)
+		}
+		var rules []string
+		for _, err := range item.Validate() {
+			rules = append(rules, err.Rule)
+		}
+		if diff := cmp.Diff([]string{"item-enclosure-required", "images-width-required"}, rules); diff != "" {
+			t.Errorf("%q: mismatch (-want +got):\n%s", srcset, diff)
+		}
+	}
+}
+
+func TestPodcastSocialInteract(t *testing.T) {
+	item := types.Item{
+		PodcastSocialInteracts: []types.PodcastSocialInteract{
+			{
+				URI:      "at://did:plc:abc/app.bsky.feed.post/3k",
+				Protocol: types.PodcastSocialProtocolATProto,
+			},
+			{
+				URI:        "https://podcastindex.social/web/@dave/108013847520053258",
+				Protocol:   types.PodcastSocialProtocolActivityPub,
+				AccountID:  pointer("@dave"),
+				AccountURL: pointer("https://podcastindex.social/web/@dave"),
+				Priority:   pointer(2),
+			},
+			{
+				URI:      "https://twitter.com/PodcastindexOrg/status/1507120226361647115",
+				Protocol: types.PodcastSocialProtocolTwitter,
+				Priority: pointer(1),
+			},
+		},
+	}
+	expected := `<item>` +
+		`<podcast:socialInteract uri="at://did:plc:abc/app.bsky.feed.post/3k" protocol="atproto"></podcast:socialInteract>` +
+		`<podcast:socialInteract uri="https://podcastindex.social/web/@dave/108013847520053258" protocol="activitypub" accountId="@dave" accountUrl="https://podcastindex.social/web/@dave" priority="2"></podcast:socialInteract>` +
+		`<podcast:socialInteract uri="https://twitter.com/PodcastindexOrg/status/1507120226361647115" protocol="twitter" priority="1"></podcast:socialInteract>` +
+		`</item>`
+
+	output, err := xml.Marshal(&item)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, string(output)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	var decoded types.Item
+	if err := xml.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(item, decoded, cmpOptions...); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	sorted := types.PodcastSocialInteractsByPriority(item.PodcastSocialInteracts)
+	var protocols []types.PodcastSocialProtocol
+	for _, interact := range sorted {
+		protocols = append(protocols, interact.Protocol)
+	}
+	expectedProtocols := []types.PodcastSocialProtocol{
+		types.PodcastSocialProtocolTwitter,
+		types.PodcastSocialProtocolActivityPub,
+		types.PodcastSocialProtocolATProto,
+	}
+	if diff := cmp.Diff(expectedProtocols, protocols); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if item.PodcastSocialInteracts[0].Protocol != types.PodcastSocialProtocolATProto {
+		t.Errorf("expected the original order to be kept")
+	}
+
+	disabled := append(item.PodcastSocialInteracts, types.PodcastSocialInteract{Protocol: types.PodcastSocialProtocolDisabled})
+	if sorted := types.PodcastSocialInteractsByPriority(disabled); sorted != nil {
+		t.Errorf("expected no threads, got %v", sorted)
+	}
+
+	output, err = xml.Marshal(&disabled[3])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(`<podcast:socialInteract protocol="disabled"></podcast:socialInteract>`, string(output)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestPodcastBlock(t *testing.T) {
+	channel := types.Channel{
+		PodcastBlocks: []types.PodcastBlock{
+			{IsBlocked: true},
+			{Service: &types.PodcastServiceSpotify, IsBlocked: false},
+			{Service: &types.PodcastServiceApple, IsBlocked: true},
+		},
+	}
+	expected := `<channel>` +
+		`<podcast:block>yes</podcast:block>` +
+		`<podcast:block id="spotify">no</podcast:block>` +
+		`<podcast:block id="apple">yes</podcast:block>` +
+		`</channel>`
+
+	output, err := xml.Marshal(&channel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, string(output)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	var decoded types.Channel
+	if err := xml.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(channel, decoded, cmpOptions...); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	tests := []struct {
+		blocks    []types.PodcastBlock
+		service   types.PodcastService
+		isBlocked bool
+	}{
+		{blocks: nil, service: types.PodcastServiceSpotify, isBlocked: false},
+		{blocks: channel.PodcastBlocks, service: types.PodcastServiceSpotify, isBlocked: false},
+		{blocks: channel.PodcastBlocks, service: types.PodcastServiceApple, isBlocked: true},
+		{blocks: channel.PodcastBlocks, service: types.PodcastServiceYouTube, isBlocked: true},
+		{blocks: channel.PodcastBlocks[2:], service: types.PodcastServiceYouTube, isBlocked: false},
+		{
+			blocks: []types.PodcastBlock{
+				{Service: &types.PodcastServiceYouTube, IsBlocked: true},
+				{IsBlocked: false},
+			},
+			service:   types.PodcastServiceYouTube,
+			isBlocked: true,
+		},
+	}
+	for i, test := range tests {
+		c := types.Channel{PodcastBlocks: test.blocks}
+		if isBlocked := c.IsBlocked(test.service); isBlocked != test.isBlocked {
+			t.Errorf("%d: expected %t for %s, got %t", i, test.isBlocked, test.service, isBlocked)
+		}
+	}
+
+	for _, value := range []string{"", "maybe"} {
+		var block types.PodcastBlock
+		err := xml.Unmarshal([]byte(`<podcast:block>`+value+`</podcast:block>`), &block)
+		if err == nil {
+			t.Errorf("%q: expected an error", value)
+		}
+	}
+}
+
+func TestRRule(t *testing.T) {
+	rules := map[string]types.RRule{
+		"FREQ=DAILY": {Frequency: types.RRuleFrequencyDaily},
+		"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=10": {
+			Frequency: types.RRuleFrequencyWeekly,
+			Interval:  2,
+			ByDay:     []types.RRuleWeekday{{Weekday: time.Monday}, {Weekday: time.Thursday}},
+			Count:     10,
+		},
+		"FREQ=MONTHLY;BYDAY=1MO,-1FR;UNTIL=20231231T235959Z": {
+			Frequency: types.RRuleFrequencyMonthly,
+			ByDay:     []types.RRuleWeekday{{Ordinal: 1, Weekday: time.Monday}, {Ordinal: -1, Weekday: time.Friday}},
+			Until:     pointer(time.Date(2023, time.December, 31, 23, 59, 59, 0, time.UTC)),
+		},
+	}
+	for s, rule := range rules {
+		parsed, err := types.ParseRRule(s)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", s, err)
+			continue
+		}
+		if diff := cmp.Diff(&rule, parsed); diff != "" {
+			t.Errorf("%s: mismatch (-want +got):\n%s", s, diff)
+		}
+		if diff := cmp.Diff(s, rule.String()); diff != "" {
+			t.Errorf("%s: mismatch (-want +got):\n%s", s, diff)
+		}
+	}
+
+	lenient := map[string]string{
+		"RRULE:freq=monthly;byday=+2we": "FREQ=MONTHLY;BYDAY=2WE",
+		"rrule:FREQ=WEEKLY":             "FREQ=WEEKLY",
+		"FREQ=YEARLY;INTERVAL=1":        "FREQ=YEARLY",
+		"FREQ=DAILY;UNTIL=20230101":     "FREQ=DAILY;UNTIL=20230101T000000Z",
+	}
+	for s, expected := range lenient {
+		parsed, err := types.ParseRRule(s)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", s, err)
+			continue
+		}
+		if diff := cmp.Diff(expected, parsed.String()); diff != "" {
+			t.Errorf("%s: mismatch (-want +got):\n%s", s, diff)
+		}
+	}
+
+	for _, s := range []string{
+		"",
+		"FREQ=FORTNIGHTLY",
+		"INTERVAL=2",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=5;UNTIL=20230101",
+		"FREQ=WEEKLY;BYDAY=1MO",
+		"FREQ=MONTHLY;BYDAY=0MO",
+		"FREQ=MONTHLY;BYDAY=MON",
+		"FREQ=MONTHLY;BYMONTHDAY=1",
+		"FREQ=DAILY;UNTIL=tomorrow",
+	} {
+		if _, err := types.ParseRRule(s); err == nil {
+			t.Errorf("%q: expected an error", s)
+		}
+	}
+}
+
+func TestPodcastUpdateFrequency(t *testing.T) {
+	frequency := types.PodcastUpdateFrequency{
+		Label:    "Twice a week",
+		Complete: pointer(false),
+		DTStart:  pointer(time.Date(2023, time.January, 2, 0, 0, 0, 0, time.UTC)),
+		RRule: &types.RRule{
+			Frequency: types.RRuleFrequencyWeekly,
+			ByDay:     []types.RRuleWeekday{{Weekday: time.Monday}, {Weekday: time.Thursday}},
+		},
+	}
+	expected := `<podcast:updateFrequency complete="false" dtstart="2023-01-02" rrule="FREQ=WEEKLY;BYDAY=MO,TH">Twice a week</podcast:updateFrequency>`
+
+	output, err := xml.Marshal(&frequency)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(expected, string(output)); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	var decoded types.PodcastUpdateFrequency
+	if err := xml.Unmarshal(output, &decoded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(frequency, decoded, cmpOptions...); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	err = xml.Unmarshal([]byte(`<podcast:updateFrequency dtstart="2023-01-02T09:30:00" rrule="FREQ=DAILY">Daily</podcast:updateFrequency>`), &decoded)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := time.Date(2023, time.January, 2, 9, 30, 0, 0, time.UTC); !decoded.DTStart.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, decoded.DTStart)
+	}
+
+	for _, input := range []string{
+		`<podcast:updateFrequency dtstart="January 2nd">Weekly</podcast:updateFrequency>`,
+		`<podcast:updateFrequency rrule="FREQ=WEEKLY;BYSETPOS=1">Weekly</podcast:updateFrequency>`,
+	} {
+		if err := xml.Unmarshal([]byte(input), &decoded); err == nil {
+			t.Errorf("%s: expected an error", input)
+		}
+	}
+
+	date := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		rrule    string
+		dtstart  time.Time
+		after    time.Time
+		n        int
+		expected []time.Time
+	}{
+		{
+			rrule:    "FREQ=WEEKLY;BYDAY=MO,TH",
+			dtstart:  date(2023, time.January, 2, 9),
+			after:    date(2023, time.January, 10, 0),
+			n:        3,
+			expected: []time.Time{date(2023, time.January, 12, 9), date(2023, time.January, 16, 9), date(2023, time.January, 19, 9)},
+		},
+		{
+			// DTSTART is always the first occurrence.
+			rrule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=TH",
+			dtstart:  date(2023, time.January, 2, 9),
+			after:    date(2023, time.January, 1, 0),
+			n:        3,
+			expected: []time.Time{date(2023, time.January, 2, 9), date(2023, time.January, 5, 9), date(2023, time.January, 19, 9)},
+		},
+		{
+			rrule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart:  date(2023, time.January, 27, 18),
+			after:    date(2023, time.February, 1, 0),
+			n:        3,
+			expected: []time.Time{date(2023, time.February, 24, 18), date(2023, time.March, 31, 18), date(2023, time.April, 28, 18)},
+		},
+		{
+			// Months without a 31st are skipped.
+			rrule:    "FREQ=MONTHLY",
+			dtstart:  date(2023, time.January, 31, 0),
+			after:    date(2023, time.January, 31, 0),
+			n:        2,
+			expected: []time.Time{date(2023, time.March, 31, 0), date(2023, time.May, 31, 0)},
+		},
+		{
+			rrule:    "FREQ=DAILY;INTERVAL=2;COUNT=3",
+			dtstart:  date(2023, time.January, 1, 6),
+			after:    date(2022, time.December, 31, 0),
+			n:        5,
+			expected: []time.Time{date(2023, time.January, 1, 6), date(2023, time.January, 3, 6), date(2023, time.January, 5, 6)},
+		},
+		{
+			rrule:    "FREQ=WEEKLY;UNTIL=20230120T000000Z",
+			dtstart:  date(2023, time.January, 2, 9),
+			after:    date(2023, time.January, 10, 0),
+			n:        5,
+			expected: []time.Time{date(2023, time.January, 16, 9)},
+		},
+		{
+			rrule:    "FREQ=DAILY",
+			dtstart:  date(2000, time.January, 1, 10),
+			after:    date(2023, time.June, 15, 12),
+			n:        2,
+			expected: []time.Time{date(2023, time.June, 16, 10), date(2023, time.June, 17, 10)},
+		},
+		{
+			rrule:    "FREQ=HOURLY;INTERVAL=12;BYDAY=SA",
+			dtstart:  date(2023, time.January, 6, 23),
+			after:    date(2023, time.January, 6, 23),
+			n:        3,
+			expected: []time.Time{date(2023, time.January, 7, 11), date(2023, time.January, 7, 23), date(2023, time.January, 14, 11)},
+		},
+		{
+			rrule:    "FREQ=YEARLY;BYDAY=1MO",
+			dtstart:  date(2023, time.January, 2, 8),
+			after:    date(2023, time.January, 2, 8),
+			n:        2,
+			expected: []time.Time{date(2024, time.January, 1, 8), date(2025, time.January, 6, 8)},
+		},
+	}
+	for _, test := range tests {
+		rrule, err := types.ParseRRule(test.rrule)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.rrule, err)
+			continue
+		}
+		frequency := types.PodcastUpdateFrequency{DTStart: &test.dtstart, RRule: rrule}
+		next, err := frequency.Next(test.after, test.n)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.rrule, err)
+			continue
+		}
+		if diff := cmp.Diff(test.expected, next); diff != "" {
+			t.Errorf("%s: mismatch (-want +got):\n%s", test.rrule, diff)
+		}
+	}
+
+	// There is never a sixth Monday in a month.
+	rrule, err := types.ParseRRule("FREQ=MONTHLY;BYDAY=6MO")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	dtstart := date(2023, time.January, 2, 8)
+	next, err := rrule.Next(dtstart, dtstart, 1)
+	if !errors.Is(err, types.ErrRRuleSearchLimit) || next != nil {
+		t.Errorf("expected ErrRRuleSearchLimit and no times, got %v and %v", err, next)
+	}
+
+	frequency.Complete = pointer(true)
+	if next, err := frequency.Next(time.Time{}, 1); next != nil || err != nil {
+		t.Errorf("expected no times for a complete podcast, got %v and %v", next, err)
+	}
 }
@@ -0,0 +1,752 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Severity tells how serious a ValidationError is.
+type Severity string
+
+const (
+	// SeverityError marks problems that make the feed invalid.
+	SeverityError Severity = "error"
+	// SeverityWarning marks problems that consumers are likely to tolerate
+	// but that should still be fixed.
+	SeverityWarning Severity = "warning"
+)
+
+// ValidationError is a single problem found by Validate.
+type ValidationError struct {
+	Severity Severity `json:"severity"`
+	// Rule identifies the check that failed, e.g. "channel-title-required".
+	Rule string `json:"rule"`
+	// Path locates the offending element or attribute, e.g.
+	// "channel.items[3].podcast:transcript[0]@type". Elements are named as
+	// in the feed, except that items are listed under "items".
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (err ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Path, err.Message)
+}
+
+// Profile is a set of rules imposed by a particular consumer of feeds, which
+// Validate checks in addition to the basic ones.
+type Profile string
+
+const (
+	// ProfileApple checks the requirements of Apple Podcasts. Its rule IDs
+	// start with "apple-".
+	ProfileApple Profile = "apple"
+	// ProfilePodcastIndex checks the rules of the Podcasting 2.0 namespace
+	// that go beyond the presence of values. Its rule IDs start with
+	// "podcastindex-".
+	ProfilePodcastIndex Profile = "podcastindex"
+)
+
+// Validate checks the feed for missing and malformed values that would make
+// it invalid, as well as for violations of the given profiles. Problems are
+// returned in document order.
+func (rss RSS) Validate(profiles ...Profile) []ValidationError {
+	v := &validator{profiles: profiles}
+	rss.Channel.validate(v, "channel")
+	return v.errs
+}
+
+// Validate checks the channel and everything in it. Paths start with
+// "channel".
+func (c Channel) Validate(profiles ...Profile) []ValidationError {
+	v := &validator{profiles: profiles}
+	c.validate(v, "channel")
+	return v.errs
+}
+
+// Validate checks the item on its own. Paths start with "item".
+func (item Item) Validate(profiles ...Profile) []ValidationError {
+	v := &validator{profiles: profiles}
+	item.validate(v, "item")
+	return v.errs
+}
+
+// Validate checks the live item on its own. Paths start with
+// "podcast:liveItem".
+func (item PodcastLiveItem) Validate(profiles ...Profile) []ValidationError {
+	v := &validator{profiles: profiles}
+	item.validate(v, "podcast:liveItem")
+	return v.errs
+}
+
+// Validate checks the chapters file. If episodeDuration is positive, chapters
+// must also start within it. Paths start with "chapters".
+func (chapters JSONChapters) Validate(episodeDuration time.Duration) []ValidationError {
+	v := &validator{}
+	chapters.validate(v, "chapters", episodeDuration)
+	return v.errs
+}
+
+// validator collects the problems found while walking a feed.
+type validator struct {
+	profiles []Profile
+	errs     []ValidationError
+}
+
+// uses reports whether the rules of profile are to be checked.
+func (v *validator) uses(profile Profile) bool {
+	for _, p := range v.profiles {
+		if p == profile {
+			return true
+		}
+	}
+	return false
+}
+
+func (v *validator) error(path, rule, format string, args ...interface{}) {
+	v.report(SeverityError, path, rule, format, args...)
+}
+
+func (v *validator) warning(path, rule, format string, args ...interface{}) {
+	v.report(SeverityWarning, path, rule, format, args...)
+}
+
+func (v *validator) report(severity Severity, path, rule, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{
+		Severity: severity,
+		Rule:     rule,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// requireText reports rule if s is blank.
+func (v *validator) requireText(s string, path, rule, what string) bool {
+	if strings.TrimSpace(s) == "" {
+		v.error(path, rule, "%s is required", what)
+		return false
+	}
+	return true
+}
+
+// requireTextPtr reports rule if s is nil or blank.
+func (v *validator) requireTextPtr(s *string, path, rule, what string) bool {
+	if s == nil {
+		v.error(path, rule, "%s is required", what)
+		return false
+	}
+	return v.requireText(*s, path, rule, what)
+}
+
+// isEmail reports whether s looks like a plain email address.
+func isEmail(s string) bool {
+	local, domain, ok := strings.Cut(s, "@")
+	return ok && local != "" && strings.Contains(domain, ".") && !strings.ContainsAny(s, " \t<>()")
+}
+
+// isRSSEmail reports whether s is an email address in the form RSS 2.0 uses
+// for people, optionally followed by a name in parentheses, e.g.
+// "jane@example.com (Jane Doe)".
+func isRSSEmail(s string) bool {
+	s = strings.TrimSpace(s)
+	if address, name, ok := strings.Cut(s, " "); ok {
+		name = strings.TrimSpace(name)
+		if !strings.HasPrefix(name, "(") || !strings.HasSuffix(name, ")") {
+			return false
+		}
+		s = address
+	}
+	return isEmail(s)
+}
+
+func (v *validator) checkRSSEmail(s *string, path, rule string) {
+	if s != nil && !isRSSEmail(*s) {
+		v.warning(path, rule, "\"%s\" is not an email address optionally followed by a name in parentheses", *s)
+	}
+}
+
+// checkDate reports a date that is present but zero, which is how decoding
+// leaves dates that cannot be parsed.
+func (v *validator) checkDate(d *Date, path string) {
+	if d != nil && time.Time(*d).IsZero() {
+		v.error(path, "date-invalid", "date could not be parsed")
+	}
+}
+
+func index(path string, i int) string {
+	return fmt.Sprintf("%s[%d]", path, i)
+}
+
+func (c Channel) validate(v *validator, path string) {
+	v.requireTextPtr(c.Title, path+".title", "channel-title-required", "title")
+	v.requireTextPtr(c.Link, path+".link", "channel-link-required", "link")
+	if c.Description == nil {
+		v.error(path+".description", "channel-description-required", "description is required")
+	} else {
+		v.requireText(c.Description.Description, path+".description", "channel-description-required", "description")
+	}
+	for i, category := range c.Categories {
+		v.requireText(category.Category, index(path+".category", i), "category-required", "category")
+	}
+	if c.Cloud != nil {
+		c.Cloud.validate(v, path+".cloud")
+	}
+	if c.Image != nil {
+		c.Image.validate(v, path+".image")
+	}
+	v.checkDate(c.LastBuildDate, path+".lastBuildDate")
+	v.checkRSSEmail(c.ManagingEditor, path+".managingEditor", "managing-editor-email-invalid")
+	v.checkDate(c.PubDate, path+".pubDate")
+	if c.SkipDays != nil {
+		c.SkipDays.validate(v, path+".skipDays")
+	}
+	if c.SkipHours != nil {
+		c.SkipHours.validate(v, path+".skipHours")
+	}
+	if c.TTL != nil && *c.TTL < 0 {
+		v.error(path+".ttl", "ttl-negative", "ttl must not be a negative number of minutes")
+	}
+	if c.TextInput != nil {
+		c.TextInput.validate(v, path+".textInput")
+	}
+	v.checkRSSEmail(c.WebMaster, path+".webMaster", "web-master-email-invalid")
+	if c.AtomLink != nil {
+		v.requireText(c.AtomLink.Href, path+".atom:link@href", "atom-link-href-required", "href")
+	}
+	for i, category := range c.GooglePlayCategories {
+		category.validate(v, index(path+".googleplay:category", i))
+	}
+	if c.GooglePlayImage != nil {
+		v.requireText(c.GooglePlayImage.URL, path+".googleplay:image@href", "googleplay-image-href-required", "href")
+	}
+	if c.ITunesImage != nil {
+		c.ITunesImage.validate(v, path+".itunes:image")
+	}
+	if c.ITunesType != nil {
+		c.ITunesType.validate(v, path+".itunes:type")
+	}
+	if c.ITunesOwner != nil {
+		p := path + ".itunes:owner"
+		v.requireText(c.ITunesOwner.Email, p+".itunes:email", "itunes-owner-email-required", "owner's email")
+	}
+	validateBlocks(v, c.PodcastBlocks, path)
+	for i, funding := range c.PodcastFundings {
+		funding.validate(v, index(path+".podcast:funding", i))
+	}
+	if c.PodcastImages != nil {
+		c.PodcastImages.validate(v, path+".podcast:images")
+	}
+	if c.PodcastLicense != nil {
+		c.PodcastLicense.validate(v, path+".podcast:license")
+	}
+	if c.PodcastLocation != nil {
+		c.PodcastLocation.validate(v, path+".podcast:location")
+	}
+	for i, person := range c.PodcastPersons {
+		person.validate(v, index(path+".podcast:person", i))
+	}
+	if c.PodcastPublisher != nil {
+		for i, remoteItem := range c.PodcastPublisher.RemoteItems {
+			remoteItem.validate(v, index(path+".podcast:publisher.podcast:remoteItem", i))
+		}
+	}
+	for i, remoteItem := range c.PodcastRemoteItems {
+		remoteItem.validate(v, index(path+".podcast:remoteItem", i))
+	}
+	for i, txt := range c.PodcastTXTs {
+		txt.validate(v, index(path+".podcast:txt", i))
+	}
+	for i, trailer := range c.PodcastTrailers {
+		trailer.validate(v, index(path+".podcast:trailer", i))
+	}
+	if c.PodcastUpdateFrequency != nil {
+		c.PodcastUpdateFrequency.validate(v, path+".podcast:updateFrequency")
+	}
+	if c.PodcastValue != nil {
+		c.PodcastValue.validate(v, path+".podcast:value")
+	}
+	if v.uses(ProfileApple) {
+		c.validateApple(v, path)
+	}
+	if v.uses(ProfilePodcastIndex) {
+		c.validatePodcastIndex(v, path)
+	}
+	for i, item := range c.PodcastLiveItems {
+		item.validate(v, index(path+".podcast:liveItem", i))
+	}
+	for i, item := range c.Items {
+		item.validate(v, index(path+".items", i))
+	}
+}
+
+func (item Item) validate(v *validator, path string) {
+	hasTitle := item.Title != nil && strings.TrimSpace(*item.Title) != ""
+	hasDescription := item.Description != nil && strings.TrimSpace(item.Description.Description) != ""
+	if !hasTitle && !hasDescription {
+		v.error(path+".title", "item-title-or-description-required", "either title or description is required")
+	}
+	v.checkRSSEmail(item.Author, path+".author", "author-email-invalid")
+	for i, category := range item.Categories {
+		v.requireText(category.Category, index(path+".category", i), "category-required", "category")
+	}
+	if item.Enclosure == nil {
+		v.error(path+".enclosure", "item-enclosure-required", "enclosure is required")
+	} else {
+		item.Enclosure.validate(v, path+".enclosure")
+	}
+	if item.GUID != nil {
+		v.requireText(item.GUID.GUID, path+".guid", "guid-required", "guid")
+	}
+	v.checkDate(item.PubDate, path+".pubDate")
+	if item.Source != nil {
+		v.requireText(item.Source.URL, path+".source@url", "source-url-required", "url")
+	}
+	if item.GooglePlayImage != nil {
+		v.requireText(item.GooglePlayImage.URL, path+".googleplay:image@href", "googleplay-image-href-required", "href")
+	}
+	if item.ITunesDuration != nil && *item.ITunesDuration < 0 {
+		v.error(path+".itunes:duration", "itunes-duration-invalid", "duration must be given as seconds, MM:SS or HH:MM:SS")
+	}
+	if item.ITunesEpisodeType != nil {
+		item.ITunesEpisodeType.validate(v, path+".itunes:episodeType")
+	}
+	if item.ITunesImage != nil {
+		item.ITunesImage.validate(v, path+".itunes:image")
+	}
+	for i, enclosure := range item.PodcastAlternateEnclosures {
+		enclosure.validate(v, index(path+".podcast:alternateEnclosure", i))
+	}
+	if item.PodcastChapters != nil {
+		p := path + ".podcast:chapters"
+		v.requireText(item.PodcastChapters.URL, p+"@url", "chapters-url-required", "url")
+		v.requireText(item.PodcastChapters.Mimetype, p+"@type", "chapters-type-required", "type")
+	}
+	if item.PodcastImages != nil {
+		item.PodcastImages.validate(v, path+".podcast:images")
+	}
+	if item.PodcastLicense != nil {
+		item.PodcastLicense.validate(v, path+".podcast:license")
+	}
+	if item.PodcastLocation != nil {
+		item.PodcastLocation.validate(v, path+".podcast:location")
+	}
+	for i, person := range item.PodcastPersons {
+		person.validate(v, index(path+".podcast:person", i))
+	}
+	validateSocialInteracts(v, item.PodcastSocialInteracts, path)
+	for i, soundbite := range item.PodcastSoundbites {
+		soundbite.validate(v, index(path+".podcast:soundbite", i))
+	}
+	for i, txt := range item.PodcastTXTs {
+		txt.validate(v, index(path+".podcast:txt", i))
+	}
+	for i, transcript := range item.PodcastTranscripts {
+		transcript.validate(v, index(path+".podcast:transcript", i))
+	}
+	if item.PodcastValue != nil {
+		item.PodcastValue.validate(v, path+".podcast:value")
+	}
+	if item.PSCChapters != nil {
+		item.PSCChapters.validate(v, path+".psc:chapters")
+	}
+	if v.uses(ProfileApple) {
+		item.validateApple(v, path)
+	}
+	if v.uses(ProfilePodcastIndex) {
+		item.validatePodcastIndex(v, path)
+	}
+}
+
+func (item PodcastLiveItem) validate(v *validator, path string) {
+	switch item.Status {
+	case PodcastLiveStatusPending, PodcastLiveStatusLive, PodcastLiveStatusEnded:
+	default:
+		v.error(path+"@status", "live-item-status-invalid", "status \"%s\" is not one of pending, live or ended", item.Status)
+	}
+	if item.StartTime.IsZero() {
+		v.error(path+"@start", "live-item-start-required", "start is required")
+	}
+	if item.EndTime != nil && !item.EndTime.After(item.StartTime) {
+		v.error(path+"@end", "live-item-end-before-start", "end must be after start")
+	}
+	hasTitle := item.Title != nil && strings.TrimSpace(*item.Title) != ""
+	hasDescription := item.Description != nil && strings.TrimSpace(item.Description.Description) != ""
+	if !hasTitle && !hasDescription {
+		v.error(path+".title", "item-title-or-description-required", "either title or description is required")
+	}
+	v.checkRSSEmail(item.Author, path+".author", "author-email-invalid")
+	for i, category := range item.Categories {
+		v.requireText(category.Category, index(path+".category", i), "category-required", "category")
+	}
+	if item.Enclosure == nil {
+		v.error(path+".enclosure", "item-enclosure-required", "enclosure is required")
+	} else {
+		item.Enclosure.validate(v, path+".enclosure")
+	}
+	if item.GUID != nil {
+		v.requireText(item.GUID.GUID, path+".guid", "guid-required", "guid")
+	}
+	if item.ITunesEpisodeType != nil {
+		item.ITunesEpisodeType.validate(v, path+".itunes:episodeType")
+	}
+	if item.ITunesImage != nil {
+		item.ITunesImage.validate(v, path+".itunes:image")
+	}
+	for i, enclosure := range item.PodcastAlternateEnclosures {
+		enclosure.validate(v, index(path+".podcast:alternateEnclosure", i))
+	}
+	for i, link := range item.PodcastContentLinks {
+		v.requireText(link.Href, index(path+".podcast:contentLink", i)+"@href", "content-link-href-required", "href")
+	}
+	if item.PodcastImages != nil {
+		item.PodcastImages.validate(v, path+".podcast:images")
+	}
+	if item.PodcastLocation != nil {
+		item.PodcastLocation.validate(v, path+".podcast:location")
+	}
+	for i, person := range item.PodcastPersons {
+		person.validate(v, index(path+".podcast:person", i))
+	}
+	validateSocialInteracts(v, item.PodcastSocialInteracts, path)
+	for i, soundbite := range item.PodcastSoundbites {
+		soundbite.validate(v, index(path+".podcast:soundbite", i))
+	}
+	for i, txt := range item.PodcastTXTs {
+		txt.validate(v, index(path+".podcast:txt", i))
+	}
+	for i, transcript := range item.PodcastTranscripts {
+		transcript.validate(v, index(path+".podcast:transcript", i))
+	}
+	if item.PodcastValue != nil {
+		item.PodcastValue.validate(v, path+".podcast:value")
+	}
+	if v.uses(ProfilePodcastIndex) {
+		item.validatePodcastIndex(v, path)
+	}
+}
+
+func (cloud Cloud) validate(v *validator, path string) {
+	v.requireText(cloud.Domain, path+"@domain", "cloud-domain-required", "domain")
+	if cloud.Port < 1 || cloud.Port > 65535 {
+		v.error(path+"@port", "cloud-port-out-of-range", "port must be between 1 and 65535")
+	}
+	v.requireText(cloud.Path, path+"@path", "cloud-path-required", "path")
+	v.requireText(cloud.RegisterProcedure, path+"@registerProcedure", "cloud-register-procedure-required", "registerProcedure")
+	switch cloud.Protocol {
+	case CloudProtocolXMLRPC, CloudProtocolSOAP, CloudProtocolHTTPPost:
+	default:
+		v.error(path+"@protocol", "cloud-protocol-invalid", "protocol \"%s\" is not one of \"xml-rpc\", \"soap\" and \"http-post\"", cloud.Protocol)
+	}
+}
+
+func (category GooglePlayCategory) validate(v *validator, path string) {
+	for _, c := range GooglePlayCategories {
+		if category == c {
+			return
+		}
+	}
+	v.error(path+"@text", "googleplay-category-invalid", "\"%s\" is not a Google Podcasts category", category)
+}
+
+func (image Image) validate(v *validator, path string) {
+	v.requireText(image.URL, path+".url", "image-url-required", "url")
+	v.requireText(image.Title, path+".title", "image-title-required", "title")
+	v.requireText(image.Link, path+".link", "image-link-required", "link")
+	if image.Width != nil && (*image.Width < 1 || *image.Width > MaxImageWidth) {
+		v.error(path+".width", "image-width-out-of-range", "width must be between 1 and %d", MaxImageWidth)
+	}
+	if image.Height != nil && (*image.Height < 1 || *image.Height > MaxImageHeight) {
+		v.error(path+".height", "image-height-out-of-range", "height must be between 1 and %d", MaxImageHeight)
+	}
+}
+
+func (skipDays SkipDays) validate(v *validator, path string) {
+	for i, day := range skipDays.Days {
+		if !isWeekday(day) {
+			v.error(index(path+".day", i), "skip-days-invalid", "\"%s\" is not the name of a weekday", day)
+		}
+	}
+}
+
+// isWeekday reports whether s is the English name of a day of the week.
+func isWeekday(s string) bool {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if s == d.String() {
+			return true
+		}
+	}
+	return false
+}
+
+func (skipHours SkipHours) validate(v *validator, path string) {
+	for i, hour := range skipHours.Hours {
+		if hour < 0 || hour > 23 {
+			v.error(index(path+".hour", i), "skip-hours-out-of-range", "hour must be between 0 and 23")
+		}
+	}
+}
+
+func (textInput TextInput) validate(v *validator, path string) {
+	v.requireText(textInput.Title, path+".title", "text-input-title-required", "title")
+	v.requireText(textInput.Description, path+".description", "text-input-description-required", "description")
+	v.requireText(textInput.Name, path+".name", "text-input-name-required", "name")
+	v.requireText(textInput.Link, path+".link", "text-input-link-required", "link")
+}
+
+func (enclosure Enclosure) validate(v *validator, path string) {
+	v.requireText(enclosure.URL, path+"@url", "enclosure-url-required", "url")
+	v.requireText(enclosure.Mimetype, path+"@type", "enclosure-type-required", "type")
+	if enclosure.Length < 0 {
+		v.error(path+"@length", "enclosure-length-negative", "length must not be negative")
+	} else if enclosure.Length == 0 {
+		v.warning(path+"@length", "enclosure-length-zero", "length should be the size of the file in bytes")
+	}
+}
+
+func (image ITunesImage) validate(v *validator, path string) {
+	v.requireText(image.URL, path+"@href", "itunes-image-href-required", "href")
+}
+
+func (t ITunesType) validate(v *validator, path string) {
+	switch t {
+	case ITunesTypeEpisodic, ITunesTypeSerial:
+	default:
+		v.error(path, "itunes-type-invalid", "type \"%s\" is not one of episodic or serial", t)
+	}
+}
+
+func (t ITunesEpisodeType) validate(v *validator, path string) {
+	switch t {
+	case ITunesEpisodeTypeFull, ITunesEpisodeTypeTrailer, ITunesEpisodeTypeBonus:
+	default:
+		v.error(path, "itunes-episode-type-invalid", "episode type \"%s\" is not one of full, trailer or bonus", t)
+	}
+}
+
+func validateBlocks(v *validator, blocks []PodcastBlock, path string) {
+	seen := make(map[PodcastService]bool)
+	hasAll := false
+	for i, block := range blocks {
+		p := index(path+".podcast:block", i)
+		if block.Service == nil {
+			if hasAll {
+				v.error(p, "block-duplicate", "there must be only one podcast:block without an id")
+			}
+			hasAll = true
+			continue
+		}
+		service := *block.Service
+		if seen[service] {
+			v.error(p+"@id", "block-duplicate", "there must be only one podcast:block with id \"%s\"", service)
+		} else if !isPodcastService(service) {
+			v.warning(p+"@id", "block-service-unknown", "\"%s\" is not a defined service slug", service)
+		}
+		seen[service] = true
+	}
+}
+
+func isPodcastService(service PodcastService) bool {
+	for _, s := range PodcastServices {
+		if service == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (funding PodcastFunding) validate(v *validator, path string) {
+	v.requireText(funding.URL, path+"@url", "funding-url-required", "url")
+	if utf8.RuneCountInString(funding.Caption) > 128 {
+		v.warning(path, "funding-caption-too-long", "caption should not exceed 128 characters")
+	}
+}
+
+func (images PodcastImages) validate(v *validator, path string) {
+	if len(images.Images) == 0 {
+		v.error(path+"@srcset", "images-srcset-required", "srcset is required")
+	}
+	// Candidates are not elements of their own, so their index is given in
+	// the message rather than the path.
+	p := path + "@srcset"
+	widths := make(map[int]bool)
+	for i, image := range images.Images {
+		v.requireText(image.URL, p, "images-url-required", fmt.Sprintf("candidate %d: url", i))
+		switch {
+		case image.Width <= 0:
+			v.error(p, "images-width-required", "candidate %d: a positive width descriptor is required", i)
+		case widths[image.Width]:
+			v.error(p, "images-width-duplicate", "candidate %d: width descriptor %dw is used more than once", i, image.Width)
+		}
+		widths[image.Width] = true
+	}
+}
+
+func (license PodcastLicense) validate(v *validator, path string) {
+	v.requireText(license.Value, path, "license-required", "license identifier")
+}
+
+func (location PodcastLocation) validate(v *validator, path string) {
+	v.requireText(location.Location, path, "location-required", "location name")
+	if geo := location.Geo; geo != nil {
+		if !geo.isInRange() {
+			v.error(path+"@geo", "location-geo-out-of-range", "coordinates are out of range")
+		}
+	}
+	if osm := location.OSM; osm != nil {
+		if osm.Type != 'N' && osm.Type != 'W' && osm.Type != 'R' {
+			v.error(path+"@osm", "location-osm-type-invalid", "OSM type must be N, W or R")
+		}
+	}
+}
+
+func (geo PodcastGeo) isInRange() bool {
+	return geo.Latitude >= -90 && geo.Latitude <= 90 && geo.Longitude >= -180 && geo.Longitude <= 180
+}
+
+func (person PodcastPerson) validate(v *validator, path string) {
+	v.requireText(person.Name, path, "person-name-required", "name")
+}
+
+func (remoteItem PodcastRemoteItem) validate(v *validator, path string) {
+	// A missing feedGuid is derived from feedUrl when encoding.
+	if remoteItem.FeedGUID == uuid.Nil && remoteItem.FeedURL == nil {
+		v.error(path+"@feedGuid", "remote-item-feed-guid-required", "feedGuid or feedUrl is required")
+	}
+}
+
+func validateSocialInteracts(v *validator, interacts []PodcastSocialInteract, path string) {
+	for i, interact := range interacts {
+		p := index(path+".podcast:socialInteract", i)
+		switch interact.Protocol {
+		case PodcastSocialProtocolDisabled:
+			if len(interacts) > 1 {
+				v.warning(p+"@protocol", "social-interact-disabled-with-others", "other podcast:socialInteract elements are ignored when social interaction is disabled")
+			}
+			continue
+		case PodcastSocialProtocolActivityPub, PodcastSocialProtocolATProto, PodcastSocialProtocolHive, PodcastSocialProtocolLightning,
+			PodcastSocialProtocolMatrix, PodcastSocialProtocolNostr, PodcastSocialProtocolTwitter:
+		default:
+			v.error(p+"@protocol", "social-interact-protocol-invalid", "protocol \"%s\" is not a defined social protocol", interact.Protocol)
+		}
+		v.requireText(interact.URI, p+"@uri", "social-interact-uri-required", "uri")
+	}
+}
+
+func (soundbite PodcastSoundbite) validate(v *validator, path string) {
+	if soundbite.StartTime < 0 {
+		v.error(path+"@startTime", "soundbite-start-negative", "startTime must not be negative")
+	}
+	if soundbite.Duration <= 0 {
+		v.error(path+"@duration", "soundbite-duration-positive", "duration must be positive")
+	}
+}
+
+func (txt PodcastTXT) validate(v *validator, path string) {
+	if v.requireText(txt.TXT, path, "txt-required", "text") && utf8.RuneCountInString(txt.TXT) > 4000 {
+		v.error(path, "txt-too-long", "text must not exceed 4000 characters")
+	}
+	if txt.Purpose != nil && utf8.RuneCountInString(*txt.Purpose) > 128 {
+		v.error(path+"@purpose", "txt-purpose-too-long", "purpose must not exceed 128 characters")
+	}
+}
+
+func (trailer PodcastTrailer) validate(v *validator, path string) {
+	v.requireText(trailer.Title, path, "trailer-title-required", "title")
+	v.requireText(trailer.URL, path+"@url", "trailer-url-required", "url")
+	if time.Time(trailer.PubDate).IsZero() {
+		v.error(path+"@pubdate", "trailer-pubdate-required", "pubdate is required")
+	}
+}
+
+func (frequency PodcastUpdateFrequency) validate(v *validator, path string) {
+	v.requireText(frequency.Label, path, "update-frequency-label-required", "label")
+	if frequency.RRule != nil {
+		if err := frequency.RRule.check(); err != nil {
+			v.error(path+"@rrule", "update-frequency-rrule-invalid", "%s", err)
+		}
+	}
+}
+
+func (transcript PodcastTranscript) validate(v *validator, path string) {
+	v.requireText(transcript.URL, path+"@url", "transcript-url-required", "url")
+	v.requireText(transcript.Mimetype, path+"@type", "transcript-type-required", "type")
+}
+
+func (enclosure PodcastAlternateEnclosure) validate(v *validator, path string) {
+	v.requireText(enclosure.Mimetype, path+"@type", "alternate-enclosure-type-required", "type")
+	if len(enclosure.Sources) == 0 {
+		v.error(path+".podcast:source", "alternate-enclosure-source-required", "at least one source is required")
+	}
+	for i, source := range enclosure.Sources {
+		v.requireText(source.URI, index(path+".podcast:source", i)+"@uri", "source-uri-required", "uri")
+	}
+}
+
+func (value PodcastValue) validate(v *validator, path string) {
+	v.requireText(value.Type, path+"@type", "value-type-required", "type")
+	v.requireText(value.Method, path+"@method", "value-method-required", "method")
+	if len(value.Recipients) == 0 {
+		v.error(path+".podcast:valueRecipient", "value-recipient-required", "at least one recipient is required")
+	}
+	validateRecipients(v, value.Recipients, path)
+	for i, split := range value.ValueTimeSplits {
+		p := index(path+".podcast:valueTimeSplit", i)
+		if split.Duration <= 0 {
+			v.error(p+"@duration", "value-time-split-duration-positive", "duration must be positive")
+		}
+		if split.RemotePercentage != nil && *split.RemotePercentage > 100 {
+			v.error(p+"@remotePercentage", "value-time-split-percentage-range", "remotePercentage must not exceed 100")
+		}
+		validateRecipients(v, split.Recipients, p)
+	}
+}
+
+func validateRecipients(v *validator, recipients []PodcastValueRecipient, path string) {
+	var total uint
+	for i, recipient := range recipients {
+		p := index(path+".podcast:valueRecipient", i)
+		v.requireText(recipient.Type, p+"@type", "value-recipient-type-required", "type")
+		v.requireText(recipient.Address, p+"@address", "value-recipient-address-required", "address")
+		total += recipient.Split
+	}
+	if len(recipients) > 0 && total == 0 {
+		v.error(path+".podcast:valueRecipient", "value-recipient-splits-zero", "recipients' splits must not all be zero")
+	}
+}
+
+func (chapters PSCChapters) validate(v *validator, path string) {
+	for i, chapter := range chapters.Chapters {
+		p := index(path+".psc:chapter", i)
+		v.requireText(chapter.Title, p+"@title", "psc-chapter-title-required", "title")
+		if i > 0 && chapter.Start < chapters.Chapters[i-1].Start {
+			v.warning(p+"@start", "psc-chapter-order", "chapters should be in order of their start")
+		}
+	}
+}
+
+func (chapters JSONChapters) validate(v *validator, path string, episodeDuration time.Duration) {
+	for i, chapter := range chapters.Chapters {
+		p := index(path, i)
+		startTime := time.Duration(chapter.StartTime)
+		if startTime < 0 {
+			v.error(p+".startTime", "chapters-start-negative", "startTime must not be negative")
+		} else if episodeDuration > 0 && startTime >= episodeDuration {
+			v.error(p+".startTime", "chapters-start-beyond-duration", "startTime must be within the episode's duration")
+		}
+		if i > 0 && chapter.StartTime < chapters.Chapters[i-1].StartTime {
+			v.error(p+".startTime", "chapters-start-order", "startTime must not be before that of the previous chapter")
+		}
+		if chapter.EndTime != nil && *chapter.EndTime <= chapter.StartTime {
+			v.error(p+".endTime", "chapters-end-before-start", "endTime must be after startTime")
+		}
+		if location := chapter.Location; location != nil {
+			v.requireText(location.Name, p+".location.name", "chapters-location-name-required", "name")
+			if !location.Geo.isInRange() {
+				v.error(p+".location.geo", "chapters-location-geo-out-of-range", "coordinates are out of range")
+			}
+		}
+	}
+}
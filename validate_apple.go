@@ -0,0 +1,94 @@
+package types
+
+import (
+	"net/url"
+	pathpkg "path"
+	"strings"
+)
+
+// appleEnclosureTypes are the media types Apple Podcasts accepts for
+// enclosures.
+var appleEnclosureTypes = []string{
+	"audio/mpeg",
+	"audio/x-m4a",
+	"video/mp4",
+	"video/quicktime",
+	"video/x-m4v",
+	"application/pdf",
+}
+
+func (c Channel) validateApple(v *validator, path string) {
+	v.requireTextPtr(c.Language, path+".language", "apple-language-required", "language")
+	if c.ITunesImage == nil {
+		v.error(path+".itunes:image", "apple-itunes-image-required", "itunes:image is required")
+	} else {
+		c.ITunesImage.validateApple(v, path+".itunes:image")
+	}
+	if len(c.ITunesCategories) == 0 {
+		v.error(path+".itunes:category", "apple-itunes-category-required", "at least one itunes:category is required")
+	}
+	for i, category := range c.ITunesCategories {
+		category.validateApple(v, index(path+".itunes:category", i))
+	}
+	if c.ITunesExplicit == nil {
+		v.error(path+".itunes:explicit", "apple-itunes-explicit-required", "itunes:explicit is required")
+	}
+	if c.ITunesOwner == nil {
+		v.error(path+".itunes:owner", "apple-itunes-owner-required", "itunes:owner is required")
+	} else if strings.TrimSpace(c.ITunesOwner.Email) != "" && !isEmail(c.ITunesOwner.Email) {
+		v.error(path+".itunes:owner.itunes:email", "apple-itunes-owner-email-invalid", "\"%s\" is not an email address", c.ITunesOwner.Email)
+	}
+}
+
+func (item Item) validateApple(v *validator, path string) {
+	v.requireTextPtr(item.Title, path+".title", "apple-item-title-required", "title")
+	if item.GUID == nil {
+		v.warning(path+".guid", "apple-item-guid-recommended", "guid should be set so that episodes are not duplicated")
+	}
+	if item.Enclosure != nil {
+		item.Enclosure.validateApple(v, path+".enclosure")
+	}
+	if item.ITunesImage != nil {
+		item.ITunesImage.validateApple(v, path+".itunes:image")
+	}
+}
+
+func (category ITunesCategory) validateApple(v *validator, path string) {
+	if !category.Category.IsValid() {
+		v.error(path+"@text", "apple-itunes-category-invalid", "\"%s\" is not an Apple Podcasts category", category.Category)
+		return
+	}
+	if !category.IsValid() {
+		v.error(path+".itunes:category@text", "apple-itunes-subcategory-invalid", "\"%s\" is not a subcategory of \"%s\"", *category.Subcategory, category.Category)
+	}
+}
+
+func (image ITunesImage) validateApple(v *validator, path string) {
+	if strings.TrimSpace(image.URL) == "" {
+		return
+	}
+	u, err := url.Parse(image.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		v.error(path+"@href", "apple-itunes-image-url-invalid", "\"%s\" is not an HTTP URL", image.URL)
+		return
+	}
+	// Image URLs served by CDNs often have no extension, in which case the
+	// format cannot be told from the URL.
+	switch strings.ToLower(pathpkg.Ext(u.Path)) {
+	case "", ".jpg", ".jpeg", ".png":
+	default:
+		v.error(path+"@href", "apple-itunes-image-format", "image must be a JPEG or PNG file")
+	}
+}
+
+func (enclosure Enclosure) validateApple(v *validator, path string) {
+	if strings.TrimSpace(enclosure.Mimetype) == "" {
+		return
+	}
+	for _, mimetype := range appleEnclosureTypes {
+		if strings.EqualFold(enclosure.Mimetype, mimetype) {
+			return
+		}
+	}
+	v.error(path+"@type", "apple-enclosure-type", "type \"%s\" is not supported by Apple Podcasts", enclosure.Mimetype)
+}
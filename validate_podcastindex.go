@@ -0,0 +1,148 @@
+package types
+
+import (
+	"strings"
+	"time"
+)
+
+// podcastMediums are the values defined for podcast:medium.
+var podcastMediums = []PodcastMedium{
+	PodcastMediumPodcast,
+	PodcastMediumMusic,
+	PodcastMediumVideo,
+	PodcastMediumFilm,
+	PodcastMediumAudioBook,
+	PodcastMediumNewsletter,
+	PodcastMediumBlog,
+	PodcastMediumPublisher,
+	PodcastMediumPodcastList,
+	PodcastMediumMusicList,
+	PodcastMediumVideoList,
+	PodcastMediumFilmList,
+	PodcastMediumAudioBookList,
+	PodcastMediumNewsletterList,
+	PodcastMediumBlogList,
+	PodcastMediumPublisherList,
+	PodcastMediumMixedList,
+}
+
+// podcastPersonRoles lists the groups of the podcast:person taxonomy in lower
+// case together with their roles. Read more at
+// https://github.com/Podcastindex-org/podcast-namespace/blob/main/taxonomy.json
+var podcastPersonRoles = map[string][]string{
+	"creative direction":    {"director", "assistant director", "executive producer", "senior producer", "producer", "associate producer", "development producer", "creative director"},
+	"cast":                  {"host", "co-host", "guest host", "guest", "voice actor", "narrator", "announcer", "reporter"},
+	"writing":               {"author", "editorial director", "co-writer", "writer", "songwriter", "guest writer", "story editor", "managing editor", "script editor", "script coordinator", "researcher", "editor", "fact checker", "translator", "transcriber", "logger"},
+	"audio post-production": {"studio coordinator", "technical director", "technical manager", "audio engineer", "remote recording engineer", "post production engineer"},
+	"audio production":      {"audio editor", "sound designer", "foley artist", "composer", "theme music", "music production", "music contributor"},
+	"administration":        {"production coordinator", "booking coordinator", "production assistant", "content manager", "marketing manager", "sales representative", "sales manager"},
+	"visuals":               {"graphic designer", "cover art designer"},
+	"community":             {"social media manager"},
+	"misc.":                 {"consultant", "intern"},
+	"video production":      {"camera operator", "lighting designer", "camera grip", "assistant camera"},
+	"video post-production": {"editor", "assistant editor"},
+}
+
+const (
+	// minSoundbiteDuration and maxSoundbiteDuration bound the length of a
+	// podcast:soundbite as recommended by the specification.
+	minSoundbiteDuration = 15 * time.Second
+	maxSoundbiteDuration = 120 * time.Second
+)
+
+func (c Channel) validatePodcastIndex(v *validator, path string) {
+	if c.PodcastGUID == nil {
+		v.warning(path+".podcast:guid", "podcastindex-guid-recommended", "podcast:guid should be set")
+	} else if !c.PodcastGUID.IsValid() {
+		v.error(path+".podcast:guid", "podcastindex-guid-not-uuidv5", "\"%s\" is not a version 5 UUID", *c.PodcastGUID)
+	}
+
+	if c.PodcastMedium != nil {
+		medium := *c.PodcastMedium
+		if !isPodcastMedium(medium) {
+			v.error(path+".podcast:medium", "podcastindex-medium-invalid", "\"%s\" is not a defined medium", medium)
+		} else if isListMedium(medium) {
+			if len(c.Items) > 0 || len(c.PodcastLiveItems) > 0 {
+				v.error(path+".podcast:medium", "podcastindex-list-medium-items", "a feed with medium \"%s\" may only contain podcast:remoteItem elements", medium)
+			}
+			if len(c.PodcastRemoteItems) == 0 {
+				v.warning(path+".podcast:remoteItem", "podcastindex-list-medium-remote-items", "a feed with medium \"%s\" should list podcast:remoteItem elements", medium)
+			}
+		}
+	}
+
+	if c.PodcastLocked != nil {
+		p := path + ".podcast:locked@owner"
+		if c.PodcastLocked.Owner == nil || strings.TrimSpace(*c.PodcastLocked.Owner) == "" {
+			v.error(p, "podcastindex-locked-owner-required", "owner is required")
+		} else if !isEmail(*c.PodcastLocked.Owner) {
+			v.error(p, "podcastindex-locked-owner-invalid", "\"%s\" is not an email address", *c.PodcastLocked.Owner)
+		}
+	}
+
+	for i, person := range c.PodcastPersons {
+		person.validatePodcastIndex(v, index(path+".podcast:person", i))
+	}
+}
+
+func (item Item) validatePodcastIndex(v *validator, path string) {
+	for i, person := range item.PodcastPersons {
+		person.validatePodcastIndex(v, index(path+".podcast:person", i))
+	}
+	for i, soundbite := range item.PodcastSoundbites {
+		soundbite.validatePodcastIndex(v, index(path+".podcast:soundbite", i))
+	}
+}
+
+func (item PodcastLiveItem) validatePodcastIndex(v *validator, path string) {
+	for i, person := range item.PodcastPersons {
+		person.validatePodcastIndex(v, index(path+".podcast:person", i))
+	}
+	for i, soundbite := range item.PodcastSoundbites {
+		soundbite.validatePodcastIndex(v, index(path+".podcast:soundbite", i))
+	}
+}
+
+func (person PodcastPerson) validatePodcastIndex(v *validator, path string) {
+	group, role := "cast", "host"
+	if person.Group != nil {
+		group = strings.ToLower(strings.TrimSpace(*person.Group))
+	}
+	if person.Role != nil {
+		role = strings.ToLower(strings.TrimSpace(*person.Role))
+	}
+
+	roles, ok := podcastPersonRoles[group]
+	if !ok {
+		v.error(path+"@group", "podcastindex-person-group-invalid", "\"%s\" is not a group of the taxonomy", group)
+		return
+	}
+	for _, r := range roles {
+		if r == role {
+			return
+		}
+	}
+	v.error(path+"@role", "podcastindex-person-role-invalid", "\"%s\" is not a role of group \"%s\"", role, group)
+}
+
+func (soundbite PodcastSoundbite) validatePodcastIndex(v *validator, path string) {
+	duration := time.Duration(soundbite.Duration)
+	if duration > 0 && (duration < minSoundbiteDuration || duration > maxSoundbiteDuration) {
+		v.warning(path+"@duration", "podcastindex-soundbite-duration", "duration should be between %d and %d seconds", int(minSoundbiteDuration.Seconds()), int(maxSoundbiteDuration.Seconds()))
+	}
+}
+
+func isPodcastMedium(medium PodcastMedium) bool {
+	for _, m := range podcastMediums {
+		if m == medium {
+			return true
+		}
+	}
+	return false
+}
+
+// isListMedium reports whether medium denotes a feed that lists other feeds
+// rather than containing items.
+func isListMedium(medium PodcastMedium) bool {
+	return medium == PodcastMediumMixedList || strings.HasSuffix(string(medium), "L")
+}
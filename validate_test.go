@@ -0,0 +1,612 @@
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/rssblue/types"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		rss    types.RSS
+		errors []types.ValidationError
+	}{
+		{
+			rss: types.RSS{
+				Channel: types.Channel{
+					Title:       pointer("Bookworm Podcast"),
+					Link:        pointer("https://example.com"),
+					Description: &types.Description{Description: "Podcast about books."},
+					Items: []types.Item{
+						{
+							Title: pointer("Hello World"),
+							Enclosure: &types.Enclosure{
+								URL:      "https://example.com/hello-world.mp3",
+								Length:   1024,
+								Mimetype: "audio/mpeg",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			rss: types.RSS{
+				Channel: types.Channel{
+					Link:        pointer("https://example.com"),
+					Description: &types.Description{Description: "Podcast about books."},
+					PodcastBlocks: []types.PodcastBlock{
+						{IsBlocked: true},
+						{Service: &types.PodcastServiceSpotify},
+						{Service: pointer(types.PodcastService("myspace")), IsBlocked: true},
+						{Service: &types.PodcastServiceSpotify, IsBlocked: true},
+						{IsBlocked: false},
+					},
+					PodcastUpdateFrequency: &types.PodcastUpdateFrequency{
+						RRule: &types.RRule{Frequency: "FORTNIGHTLY"},
+					},
+					PodcastValue: &types.PodcastValue{
+						Type:   "lightning",
+						Method: "keysend",
+						Recipients: []types.PodcastValueRecipient{
+							{Type: "node", Address: "02d5c1bf8b940dc9cadca86d1b0a3c37fbe39cee4c7e839e33bef9174531d27f52"},
+							{Type: "node", Address: "032f4ffbbafffbe51726ad3c164a3d0d37ec27bc67b29a159b0f49ae8ac21b8508"},
+						},
+					},
+					PodcastLiveItems: []types.PodcastLiveItem{
+						{
+							Status:    "started",
+							StartTime: time.Date(2023, time.October, 31, 11, 0, 0, 0, time.UTC),
+							EndTime:   pointer(time.Date(2023, time.October, 31, 10, 0, 0, 0, time.UTC)),
+							Title:     pointer("Live"),
+							Enclosure: &types.Enclosure{
+								URL:      "https://example.com/live.mp3",
+								Mimetype: "audio/mpeg",
+							},
+						},
+					},
+					Items: []types.Item{
+						{
+							Title: pointer("Hello World"),
+							Enclosure: &types.Enclosure{
+								URL:      "https://example.com/hello-world.mp3",
+								Length:   1024,
+								Mimetype: "audio/mpeg",
+							},
+						},
+						{
+							Description: &types.Description{Description: "No enclosure."},
+						},
+						{
+							Title: pointer("Hello Again"),
+							Enclosure: &types.Enclosure{
+								URL:      "https://example.com/hello-again.mp3",
+								Length:   1024,
+								Mimetype: "audio/mpeg",
+							},
+							PodcastTranscripts: []types.PodcastTranscript{
+								{URL: "https://example.com/hello-again.vtt"},
+							},
+						},
+					},
+				},
+			},
+			errors: []types.ValidationError{
+				{
+					Severity: types.SeverityError,
+					Rule:     "channel-title-required",
+					Path:     "channel.title",
+					Message:  "title is required",
+				},
+				{
+					Severity: types.SeverityWarning,
+					Rule:     "block-service-unknown",
+					Path:     "channel.podcast:block[2]@id",
+					Message:  `"myspace" is not a defined service slug`,
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "block-duplicate",
+					Path:     "channel.podcast:block[3]@id",
+					Message:  `there must be only one podcast:block with id "spotify"`,
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "block-duplicate",
+					Path:     "channel.podcast:block[4]",
+					Message:  "there must be only one podcast:block without an id",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "update-frequency-label-required",
+					Path:     "channel.podcast:updateFrequency",
+					Message:  "label is required",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "update-frequency-rrule-invalid",
+					Path:     "channel.podcast:updateFrequency@rrule",
+					Message:  `frequency "FORTNIGHTLY" is not one of SECONDLY, MINUTELY, HOURLY, DAILY, WEEKLY, MONTHLY or YEARLY`,
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "value-recipient-splits-zero",
+					Path:     "channel.podcast:value.podcast:valueRecipient",
+					Message:  "recipients' splits must not all be zero",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "live-item-status-invalid",
+					Path:     "channel.podcast:liveItem[0]@status",
+					Message:  `status "started" is not one of pending, live or ended`,
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "live-item-end-before-start",
+					Path:     "channel.podcast:liveItem[0]@end",
+					Message:  "end must be after start",
+				},
+				{
+					Severity: types.SeverityWarning,
+					Rule:     "enclosure-length-zero",
+					Path:     "channel.podcast:liveItem[0].enclosure@length",
+					Message:  "length should be the size of the file in bytes",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "item-enclosure-required",
+					Path:     "channel.items[1].enclosure",
+					Message:  "enclosure is required",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "transcript-type-required",
+					Path:     "channel.items[2].podcast:transcript[0]@type",
+					Message:  "type is required",
+				},
+			},
+		},
+		{
+			rss: types.RSS{
+				Channel: types.Channel{
+					Title:       pointer("Scripting News"),
+					Link:        pointer("http://www.scripting.com/"),
+					Description: &types.Description{Description: "A weblog about scripting and stuff like that."},
+					Categories:  []types.Category{{Category: " "}},
+					GooglePlayCategories: []types.GooglePlayCategory{
+						types.GooglePlayCategoryTechnology,
+						"Literature",
+					},
+					Cloud: &types.Cloud{
+						Domain:            "rpc.sys.com",
+						Path:              "/RPC2",
+						RegisterProcedure: "pingMe",
+						Protocol:          "gopher",
+					},
+					Image: &types.Image{
+						URL:   "http://www.scripting.com/gifs/tinyScriptingNews.gif",
+						Title: "Scripting News",
+						Width: pointer(200),
+					},
+					ManagingEditor: pointer("Dave Winer"),
+					SkipDays:       &types.SkipDays{Days: []string{"Saturday", "sun"}},
+					SkipHours:      &types.SkipHours{Hours: []int{0, 24}},
+					TTL:            pointer(-1),
+					WebMaster:      pointer("webmaster@example.com (Webmaster)"),
+					Items: []types.Item{
+						{
+							Title:  pointer("Venice Film Festival"),
+							Author: pointer("Dave Winer <dave@example.com>"),
+							Enclosure: &types.Enclosure{
+								URL:      "https://example.com/venice.mp3",
+								Length:   1024,
+								Mimetype: "audio/mpeg",
+							},
+							Source: &types.Source{Title: "Quotes of the Day"},
+						},
+					},
+				},
+			},
+			errors: []types.ValidationError{
+				{
+					Severity: types.SeverityError,
+					Rule:     "category-required",
+					Path:     "channel.category[0]",
+					Message:  "category is required",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "cloud-port-out-of-range",
+					Path:     "channel.cloud@port",
+					Message:  "port must be between 1 and 65535",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "cloud-protocol-invalid",
+					Path:     "channel.cloud@protocol",
+					Message:  `protocol "gopher" is not one of "xml-rpc", "soap" and "http-post"`,
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "image-link-required",
+					Path:     "channel.image.link",
+					Message:  "link is required",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "image-width-out-of-range",
+					Path:     "channel.image.width",
+					Message:  "width must be between 1 and 144",
+				},
+				{
+					Severity: types.SeverityWarning,
+					Rule:     "managing-editor-email-invalid",
+					Path:     "channel.managingEditor",
+					Message:  `"Dave Winer" is not an email address optionally followed by a name in parentheses`,
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "skip-days-invalid",
+					Path:     "channel.skipDays.day[1]",
+					Message:  `"sun" is not the name of a weekday`,
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "skip-hours-out-of-range",
+					Path:     "channel.skipHours.hour[1]",
+					Message:  "hour must be between 0 and 23",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "ttl-negative",
+					Path:     "channel.ttl",
+					Message:  "ttl must not be a negative number of minutes",
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "googleplay-category-invalid",
+					Path:     "channel.googleplay:category[1]@text",
+					Message:  `"Literature" is not a Google Podcasts category`,
+				},
+				{
+					Severity: types.SeverityWarning,
+					Rule:     "author-email-invalid",
+					Path:     "channel.items[0].author",
+					Message:  `"Dave Winer <dave@example.com>" is not an email address optionally followed by a name in parentheses`,
+				},
+				{
+					Severity: types.SeverityError,
+					Rule:     "source-url-required",
+					Path:     "channel.items[0].source@url",
+					Message:  "url is required",
+				},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		errors := test.rss.Validate()
+		diff := cmp.Diff(test.errors, errors)
+		if diff != "" {
+			t.Errorf("%d: mismatch (-want +got):\n%s", i, diff)
+		}
+	}
+}
+
+func TestValidateItem(t *testing.T) {
+	item := types.Item{
+		Enclosure: &types.Enclosure{
+			Length:   1024,
+			Mimetype: "audio/mpeg",
+		},
+		ITunesEpisodeType: pointer(types.ITunesEpisodeType("bonuses")),
+		PodcastImages: &types.PodcastImages{
+			Images: []types.PodcastImage{
+				{URL: "https://example.com/cover-art-600.png", Width: 600},
+				{URL: "https://example.com/cover-art-large.png", Width: 600},
+				{URL: "https://example.com/cover-art.png"},
+			},
+		},
+		PodcastSocialInteracts: []types.PodcastSocialInteract{
+			{Protocol: types.PodcastSocialProtocolDisabled},
+			{URI: "https://example.com/thread", Protocol: "mastodon"},
+			{Protocol: types.PodcastSocialProtocolNostr},
+		},
+	}
+	expected := []types.ValidationError{
+		{
+			Severity: types.SeverityError,
+			Rule:     "item-title-or-description-required",
+			Path:     "item.title",
+			Message:  "either title or description is required",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "enclosure-url-required",
+			Path:     "item.enclosure@url",
+			Message:  "url is required",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "itunes-episode-type-invalid",
+			Path:     "item.itunes:episodeType",
+			Message:  `episode type "bonuses" is not one of full, trailer or bonus`,
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "images-width-duplicate",
+			Path:     "item.podcast:images@srcset",
+			Message:  "candidate 1: width descriptor 600w is used more than once",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "images-width-required",
+			Path:     "item.podcast:images@srcset",
+			Message:  "candidate 2: a positive width descriptor is required",
+		},
+		{
+			Severity: types.SeverityWarning,
+			Rule:     "social-interact-disabled-with-others",
+			Path:     "item.podcast:socialInteract[0]@protocol",
+			Message:  "other podcast:socialInteract elements are ignored when social interaction is disabled",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "social-interact-protocol-invalid",
+			Path:     "item.podcast:socialInteract[1]@protocol",
+			Message:  `protocol "mastodon" is not a defined social protocol`,
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "social-interact-uri-required",
+			Path:     "item.podcast:socialInteract[2]@uri",
+			Message:  "uri is required",
+		},
+	}
+
+	diff := cmp.Diff(expected, item.Validate())
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateApple(t *testing.T) {
+	channel := types.Channel{
+		Title:       pointer("Bookworm Podcast"),
+		Link:        pointer("https://example.com"),
+		Description: &types.Description{Description: "Podcast about books."},
+		ITunesImage: &types.ITunesImage{URL: "https://example.com/cover-art.webp"},
+		ITunesCategories: []types.ITunesCategory{
+			{
+				Category:    "Society & Culture",
+				Subcategory: pointer(types.ITunesSubcategory("Documentary")),
+			},
+			{
+				Category:    "Arts",
+				Subcategory: pointer(types.ITunesSubcategory("Documentary")),
+			},
+			{
+				Category: "Literature",
+			},
+		},
+		ITunesOwner: &types.ITunesOwner{Name: "Jane Doe", Email: "jane"},
+		Items: []types.Item{
+			{
+				Title: pointer("Hello World"),
+				GUID:  &types.GUID{GUID: "hello-world"},
+				Enclosure: &types.Enclosure{
+					URL:      "https://example.com/hello-world.ogg",
+					Length:   1024,
+					Mimetype: "audio/ogg",
+				},
+			},
+		},
+	}
+	expected := []types.ValidationError{
+		{
+			Severity: types.SeverityError,
+			Rule:     "apple-language-required",
+			Path:     "channel.language",
+			Message:  "language is required",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "apple-itunes-image-format",
+			Path:     "channel.itunes:image@href",
+			Message:  "image must be a JPEG or PNG file",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "apple-itunes-subcategory-invalid",
+			Path:     "channel.itunes:category[1].itunes:category@text",
+			Message:  `"Documentary" is not a subcategory of "Arts"`,
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "apple-itunes-category-invalid",
+			Path:     "channel.itunes:category[2]@text",
+			Message:  `"Literature" is not an Apple Podcasts category`,
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "apple-itunes-explicit-required",
+			Path:     "channel.itunes:explicit",
+			Message:  "itunes:explicit is required",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "apple-itunes-owner-email-invalid",
+			Path:     "channel.itunes:owner.itunes:email",
+			Message:  `"jane" is not an email address`,
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "apple-enclosure-type",
+			Path:     "channel.items[0].enclosure@type",
+			Message:  `type "audio/ogg" is not supported by Apple Podcasts`,
+		},
+	}
+
+	diff := cmp.Diff(expected, channel.Validate(types.ProfileApple))
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	// Without the profile only the basic rules apply.
+	if errors := channel.Validate(); len(errors) != 0 {
+		t.Errorf("unexpected errors: %v", errors)
+	}
+
+	// The format of an image URL without an extension is unknown.
+	channel.ITunesImage.URL = "https://cdn.example.com/art?id=1"
+	for _, err := range channel.Validate(types.ProfileApple) {
+		if err.Rule == "apple-itunes-image-format" {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestValidatePodcastIndex(t *testing.T) {
+	channel := types.Channel{
+		Title:         pointer("Bookworm Podcast"),
+		Link:          pointer("https://example.com"),
+		Description:   &types.Description{Description: "Podcast about books."},
+		PodcastGUID:   pointer(types.PodcastGUID("917393e3-1b1e-4cef-ace4-edaa54e1f810")),
+		PodcastMedium: pointer(types.PodcastMediumMusicList),
+		PodcastLocked: &types.PodcastLocked{IsLocked: true},
+		PodcastPersons: []types.PodcastPerson{
+			{Name: "Jane Doe"},
+			{Name: "John Doe", Group: pointer("Writing"), Role: pointer("Host")},
+			{Name: "Alice Doe", Group: pointer("Catering")},
+		},
+		Items: []types.Item{
+			{
+				Title: pointer("Hello World"),
+				Enclosure: &types.Enclosure{
+					URL:      "https://example.com/hello-world.mp3",
+					Length:   1024,
+					Mimetype: "audio/mpeg",
+				},
+				PodcastSoundbites: []types.PodcastSoundbite{
+					{StartTime: 10 * types.Second, Duration: 30 * types.Second},
+					{StartTime: 60 * types.Second, Duration: 5 * types.Second},
+				},
+			},
+		},
+	}
+	expected := []types.ValidationError{
+		{
+			Severity: types.SeverityError,
+			Rule:     "podcastindex-guid-not-uuidv5",
+			Path:     "channel.podcast:guid",
+			Message:  `"917393e3-1b1e-4cef-ace4-edaa54e1f810" is not a version 5 UUID`,
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "podcastindex-list-medium-items",
+			Path:     "channel.podcast:medium",
+			Message:  `a feed with medium "musicL" may only contain podcast:remoteItem elements`,
+		},
+		{
+			Severity: types.SeverityWarning,
+			Rule:     "podcastindex-list-medium-remote-items",
+			Path:     "channel.podcast:remoteItem",
+			Message:  `a feed with medium "musicL" should list podcast:remoteItem elements`,
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "podcastindex-locked-owner-required",
+			Path:     "channel.podcast:locked@owner",
+			Message:  "owner is required",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "podcastindex-person-role-invalid",
+			Path:     "channel.podcast:person[1]@role",
+			Message:  `"host" is not a role of group "writing"`,
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "podcastindex-person-group-invalid",
+			Path:     "channel.podcast:person[2]@group",
+			Message:  `"catering" is not a group of the taxonomy`,
+		},
+		{
+			Severity: types.SeverityWarning,
+			Rule:     "podcastindex-soundbite-duration",
+			Path:     "channel.items[0].podcast:soundbite[1]@duration",
+			Message:  "duration should be between 15 and 120 seconds",
+		},
+	}
+
+	diff := cmp.Diff(expected, channel.Validate(types.ProfilePodcastIndex))
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateJSONChapters(t *testing.T) {
+	chapters := types.JSONChapters{
+		Chapters: []types.JSONChapter{
+			{StartTime: -types.Second},
+			{StartTime: 60 * types.Second, EndTime: pointer(30 * types.Second)},
+			{StartTime: 30 * types.Second, EndTime: pointer(60 * types.Second)},
+			{
+				StartTime: 90 * types.Second,
+				Location: &types.JSONChapterLocation{
+					Geo: types.PodcastGeo{Latitude: 91, Longitude: 0},
+				},
+			},
+			{StartTime: 3600 * types.Second},
+		},
+	}
+	expected := []types.ValidationError{
+		{
+			Severity: types.SeverityError,
+			Rule:     "chapters-start-negative",
+			Path:     "chapters[0].startTime",
+			Message:  "startTime must not be negative",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "chapters-end-before-start",
+			Path:     "chapters[1].endTime",
+			Message:  "endTime must be after startTime",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "chapters-start-order",
+			Path:     "chapters[2].startTime",
+			Message:  "startTime must not be before that of the previous chapter",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "chapters-location-name-required",
+			Path:     "chapters[3].location.name",
+			Message:  "name is required",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "chapters-location-geo-out-of-range",
+			Path:     "chapters[3].location.geo",
+			Message:  "coordinates are out of range",
+		},
+		{
+			Severity: types.SeverityError,
+			Rule:     "chapters-start-beyond-duration",
+			Path:     "chapters[4].startTime",
+			Message:  "startTime must be within the episode's duration",
+		},
+	}
+
+	diff := cmp.Diff(expected, chapters.Validate(time.Hour))
+	if diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	if errs := chapters.Validate(0); len(errs) != len(expected)-1 {
+		t.Errorf("expected the duration not to be checked, got %v", errs)
+	}
+}